@@ -0,0 +1,56 @@
+package stringtyper
+
+import "strconv"
+
+// HugeIntPolicy decides the type of integers too big for int64 and uint64,
+// such as 40 digit identifiers.
+type HugeIntPolicy int
+
+const (
+	// HugeIntBigInt makes a column of integers big.Int when some are too
+	// big for float64 to hold exactly. It is the default.
+	HugeIntBigInt HugeIntPolicy = iota
+	// HugeIntString makes a column string when an integer is too big for
+	// float64 to hold exactly, so that no digits are lost.
+	HugeIntString
+	// HugeIntFloat does not consider big.Int, so a column with a huge
+	// integer is a float, losing digits.
+	HugeIntFloat
+)
+
+// SetHugeInts sets how integers too big for int64 and uint64 are typed.
+func (ti *StringTyper) SetHugeInts(p HugeIntPolicy) {
+	ti.hugeInts = p
+	ti.alwaysBigInt = p == HugeIntBigInt && !ti.disabled[CandidateBigInt]
+}
+
+// checkBigInt checks n, which is neither an int64 nor a uint64.
+func (ti *StringTyper) checkBigInt(n *number) {
+	if ti.hugeInts == HugeIntFloat {
+		return
+	}
+
+	v := n.v
+	if !n.bigInt() {
+		ti.reject(CandidateBigInt, &strconv.NumError{Func: "ParseBigInt", Num: v, Err: strconv.ErrSyntax})
+		return
+	}
+	ti.checkIntBase(v)
+	// Whether float64 holds it only matters while big.Int is possible
+	if !ti.alwaysBigInt && !ti.tolerant() && ti.hugeInts != HugeIntString {
+		return
+	}
+
+	// Huge integers float64 holds without losing digits, e.g.
+	// math.MaxFloat64 written out, are left to the floats
+	if ti.intLiteralBase == 10 {
+		digits, point, _ := parseDecimal(v, false)
+		if n.err64 == nil && exactFloat(digits, point, n.f64, 64) {
+			return
+		}
+	}
+	ti.hugeIntLoss = true
+	if ti.hugeInts == HugeIntString {
+		ti.rejectNumber(&strconv.NumError{Func: "ParseInt", Num: v, Err: strconv.ErrRange})
+	}
+}
@@ -0,0 +1,66 @@
+package stringtyper
+
+import (
+	"testing"
+)
+
+type BigIntColumnTest struct {
+	column    []Column
+	opts      []Option
+	candidate Candidate
+}
+
+var testCasesBigInt = []BigIntColumnTest{
+	BigIntColumnTest{
+		column:    []Column{"1", "1234567890123456789012345678901234567890"},
+		candidate: CandidateBigInt,
+	},
+	BigIntColumnTest{
+		column:    []Column{"-1234567890123456789012345678901234567890", "", "18446744073709551616"},
+		candidate: CandidateBigInt,
+	},
+	BigIntColumnTest{
+		column:    []Column{"1234567890123456789012345678901234567890"},
+		opts:      []Option{WithHugeInts(HugeIntString)},
+		candidate: CandidateString,
+	},
+	BigIntColumnTest{
+		column:    []Column{"123456789012345678901234567890"},
+		opts:      []Option{WithHugeInts(HugeIntFloat)},
+		candidate: CandidateFloat32,
+	},
+	BigIntColumnTest{
+		column:    []Column{"0xFFFFFFFFFFFFFFFFFFFFFFFF", "0b1"},
+		opts:      []Option{WithGoIntLiterals(true)},
+		candidate: CandidateBigInt,
+	},
+	// Not all integers
+	BigIntColumnTest{
+		column:    []Column{"1234567890123456789012345678901234567890", "1.5"},
+		candidate: CandidateFloat64,
+	},
+	// float64 holds it exactly
+	BigIntColumnTest{
+		column:    []Column{"100000000000000000000"},
+		candidate: CandidateFloat32,
+	},
+	BigIntColumnTest{
+		column:    []Column{"1234567890123456789012345678901234567890"},
+		opts:      []Option{WithCandidates(CandidateFloat64)},
+		candidate: CandidateFloat64,
+	},
+}
+
+func TestCasesBigInt(t *testing.T) {
+	for _, test := range testCasesBigInt {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if c := ti.Candidate(); c != test.candidate {
+			t.Error(test.column, test.candidate, c, ti.Result().Rejected)
+		}
+	}
+}
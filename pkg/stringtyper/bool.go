@@ -0,0 +1,163 @@
+package stringtyper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BoolVocabulary is a set of tokens for true and false.
+type BoolVocabulary struct {
+	Name            string
+	True            []string
+	False           []string
+	CaseInsensitive bool
+}
+
+var (
+	// BoolStrconv is what strconv.ParseBool accepts.
+	BoolStrconv = BoolVocabulary{
+		Name:  "strconv",
+		True:  []string{"1", "t", "T", "TRUE", "true", "True"},
+		False: []string{"0", "f", "F", "FALSE", "false", "False"},
+	}
+	BoolYesNo = BoolVocabulary{
+		Name:            "yes/no",
+		True:            []string{"yes", "y"},
+		False:           []string{"no", "n"},
+		CaseInsensitive: true,
+	}
+	BoolOnOff = BoolVocabulary{
+		Name:            "on/off",
+		True:            []string{"on"},
+		False:           []string{"off"},
+		CaseInsensitive: true,
+	}
+)
+
+// BoolPolicy decides between bool and integer for values, such as "0" and
+// "1", that are both.
+type BoolPolicy int
+
+const (
+	// PreferBool makes a column of 0s and 1s a bool column. It is the default.
+	PreferBool BoolPolicy = iota
+	// PreferInteger makes a column of 0s and 1s an integer column.
+	PreferInteger
+	// RequireWordBool does not accept numbers, e.g. "0" and "1", as bools.
+	RequireWordBool
+)
+
+// DefaultBoolVocabularies are the vocabularies a new StringTyper accepts.
+var DefaultBoolVocabularies = []BoolVocabulary{BoolStrconv}
+
+// SetBoolVocabularies replaces the vocabularies used to detect bools, in
+// order of preference. Every value must come from the same vocabulary.
+// Calling it with no vocabularies disables bool detection.
+func (ti *StringTyper) SetBoolVocabularies(vocabularies ...BoolVocabulary) {
+	ti.boolVocabularies = append([]BoolVocabulary(nil), vocabularies...)
+	ti.alwaysBoolVocabulary = make([]bool, len(vocabularies))
+	for i := range ti.alwaysBoolVocabulary {
+		ti.alwaysBoolVocabulary[i] = true
+	}
+	ti.alwaysBool = len(vocabularies) > 0 && !ti.disabled[CandidateBool]
+}
+
+// SetBoolPolicy sets how a column that is both bool and integer is typed.
+func (ti *StringTyper) SetBoolPolicy(p BoolPolicy) {
+	ti.boolPolicy = p
+}
+
+// Ambiguous reports whether every value is both a bool and an integer, as
+// with a column of 0s and 1s. The BoolPolicy decides which is inferred.
+func (ti *StringTyper) Ambiguous() bool {
+	return ti.count > 0 && ti.ok(CandidateBool) && (ti.anyOK(unsignedCandidates) || ti.anyOK(signedCandidates))
+}
+
+// BoolVocabulary returns the name of the first vocabulary every value came
+// from, or "" if the values are not all bools.
+func (ti *StringTyper) BoolVocabulary() string {
+	if !ti.ok(CandidateBool) {
+		return ""
+	}
+	for i, ok := range ti.alwaysBoolVocabulary {
+		if ok {
+			return ti.boolVocabularies[i].Name
+		}
+	}
+	return ""
+}
+
+func (ti *StringTyper) checkBool(v string) {
+	if !ti.checking(CandidateBool) {
+		return
+	}
+
+	// With a tolerance, a value that is no bool is a failure and rules no
+	// vocabulary out, so those failed before the first match are ruled out
+	// once it is found
+	first, folded := -1, false
+	for i, vocabulary := range ti.boolVocabularies {
+		if !ti.alwaysBoolVocabulary[i] {
+			continue
+		}
+		ok, f := ti.boolToken(vocabulary, v)
+		switch {
+		case ok:
+			if first < 0 {
+				first = i
+			}
+			folded = folded || f
+		case first >= 0 || !ti.tolerant():
+			ti.alwaysBoolVocabulary[i] = false
+		}
+	}
+
+	if first < 0 {
+		ti.reject(CandidateBool, &strconv.NumError{Func: "ParseBool", Num: v, Err: strconv.ErrSyntax})
+		return
+	}
+	for i := 0; i < first; i++ {
+		ti.alwaysBoolVocabulary[i] = false
+	}
+	if folded {
+		ti.foldedCount++
+	}
+}
+
+// boolToken reports whether v is a token of the vocabulary, and whether it
+// only is once case folded.
+func (ti *StringTyper) boolToken(b BoolVocabulary, v string) (ok, folded bool) {
+	_, ok = b.parse(v, b.CaseInsensitive)
+	if !ok && ti.caseFold {
+		_, ok = b.parse(v, true)
+		folded = ok
+	}
+	if ok && ti.boolPolicy == RequireWordBool && isNumber(v) {
+		return false, false
+	}
+	return ok, folded
+}
+
+func (b BoolVocabulary) parse(v string, fold bool) (bool, bool) {
+	if match(b.True, v, fold) {
+		return true, true
+	}
+	if match(b.False, v, fold) {
+		return false, true
+	}
+	return false, false
+}
+
+func match(tokens []string, v string, fold bool) bool {
+	for _, t := range tokens {
+		if t == v || fold && strings.EqualFold(t, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(v string) bool {
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
@@ -0,0 +1,158 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type BoolColumnTest struct {
+	column       []Column
+	vocabularies []BoolVocabulary
+	kind         reflect.Kind
+	vocabulary   string
+}
+
+var testCasesBool = []BoolColumnTest{
+	BoolColumnTest{
+		column:     []Column{"true", "F", "1"},
+		kind:       reflect.Bool,
+		vocabulary: "strconv",
+	},
+	BoolColumnTest{
+		column: []Column{"Y", "N", "y"},
+		kind:   reflect.String,
+	},
+	BoolColumnTest{
+		column:       []Column{"Y", "N", "y", "Yes", "NO"},
+		vocabularies: []BoolVocabulary{BoolStrconv, BoolYesNo},
+		kind:         reflect.Bool,
+		vocabulary:   "yes/no",
+	},
+	BoolColumnTest{
+		column:       []Column{"on", "OFF"},
+		vocabularies: []BoolVocabulary{BoolStrconv, BoolYesNo, BoolOnOff},
+		kind:         reflect.Bool,
+		vocabulary:   "on/off",
+	},
+	// Vocabularies can't be mixed
+	BoolColumnTest{
+		column:       []Column{"on", "no"},
+		vocabularies: []BoolVocabulary{BoolYesNo, BoolOnOff},
+		kind:         reflect.String,
+	},
+	BoolColumnTest{
+		column:       []Column{"Oui", "non"},
+		vocabularies: []BoolVocabulary{BoolVocabulary{Name: "fr", True: []string{"oui"}, False: []string{"non"}}},
+		kind:         reflect.String,
+	},
+	BoolColumnTest{
+		column:       []Column{"oui", "non"},
+		vocabularies: []BoolVocabulary{BoolVocabulary{Name: "fr", True: []string{"oui"}, False: []string{"non"}}},
+		kind:         reflect.Bool,
+		vocabulary:   "fr",
+	},
+	// No vocabularies
+	BoolColumnTest{
+		column:       []Column{"true", "false"},
+		vocabularies: []BoolVocabulary{},
+		kind:         reflect.String,
+	},
+	BoolColumnTest{
+		column:       []Column{"1", "0"},
+		vocabularies: []BoolVocabulary{},
+		kind:         reflect.Uint8,
+	},
+}
+
+func TestCasesBool(t *testing.T) {
+	for _, test := range testCasesBool {
+		ti := NewStringTyper()
+		if test.vocabularies != nil {
+			ti.SetBoolVocabularies(test.vocabularies...)
+		}
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if v := ti.BoolVocabulary(); v != test.vocabulary {
+			t.Error(test.column, "vocabulary", test.vocabulary, v)
+		}
+	}
+}
+
+type BoolPolicyColumnTest struct {
+	column    []Column
+	policy    BoolPolicy
+	kind      reflect.Kind
+	ambiguous bool
+	viable    []Candidate
+}
+
+var testCasesBoolPolicy = []BoolPolicyColumnTest{
+	BoolPolicyColumnTest{
+		column:    []Column{"0", "1", "1"},
+		policy:    PreferBool,
+		kind:      reflect.Bool,
+		ambiguous: true,
+		viable: []Candidate{CandidateBool, CandidateUint8, CandidateUint16, CandidateUint32, CandidateUint64,
+			CandidateInt8, CandidateInt16, CandidateInt32, CandidateInt64, CandidateBigInt, CandidateFloat32, CandidateFloat64, CandidateDecimal,
+			CandidateComplex64, CandidateComplex128, CandidateString},
+	},
+	BoolPolicyColumnTest{
+		column:    []Column{"0", "1", "1"},
+		policy:    PreferInteger,
+		kind:      reflect.Uint8,
+		ambiguous: true,
+	},
+	BoolPolicyColumnTest{
+		column:    []Column{"true", "false"},
+		policy:    PreferInteger,
+		kind:      reflect.Bool,
+		ambiguous: false,
+		viable:    []Candidate{CandidateBool, CandidateString},
+	},
+	BoolPolicyColumnTest{
+		column:    []Column{"0", "1", "1"},
+		policy:    RequireWordBool,
+		kind:      reflect.Uint8,
+		ambiguous: false,
+	},
+	BoolPolicyColumnTest{
+		column:    []Column{"true", "F"},
+		policy:    RequireWordBool,
+		kind:      reflect.Bool,
+		ambiguous: false,
+	},
+	BoolPolicyColumnTest{
+		column:    []Column{"true", "0"},
+		policy:    RequireWordBool,
+		kind:      reflect.String,
+		ambiguous: false,
+		viable:    []Candidate{CandidateString},
+	},
+}
+
+func TestCasesBoolPolicy(t *testing.T) {
+	for _, test := range testCasesBoolPolicy {
+		ti := NewStringTyper()
+		ti.SetBoolPolicy(test.policy)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.policy, test.kind, k)
+		}
+		if a := ti.Ambiguous(); a != test.ambiguous {
+			t.Error(test.column, test.policy, "ambiguous", test.ambiguous, a)
+		}
+		if test.viable != nil && !reflect.DeepEqual(ti.Viable(), test.viable) {
+			t.Error(test.column, test.policy, "viable", test.viable, ti.Viable())
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package stringtyper
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Candidate is one of the Go types a StringTyper considers for a column.
+// The constants are in order of preference: the first candidate that every
+// value satisfies is the inferred type.
+type Candidate int
+
+const (
+	CandidateBool Candidate = iota
+	CandidateTime
+	CandidateUint8
+	CandidateUint16
+	CandidateUint32
+	CandidateUint64
+	CandidateInt8
+	CandidateInt16
+	CandidateInt32
+	CandidateInt64
+	CandidateBigInt
+	CandidateFloat32
+	CandidateFloat64
+	CandidateDecimal
+	CandidateComplex64
+	CandidateComplex128
+	CandidateDuration
+	CandidateString
+	numCandidates
+)
+
+var candidateTypes = [numCandidates]reflect.Type{
+	CandidateBool:       reflect.TypeOf(false),
+	CandidateTime:       reflect.TypeOf(time.Time{}),
+	CandidateUint8:      reflect.TypeOf(uint8(0)),
+	CandidateUint16:     reflect.TypeOf(uint16(0)),
+	CandidateUint32:     reflect.TypeOf(uint32(0)),
+	CandidateUint64:     reflect.TypeOf(uint64(0)),
+	CandidateInt8:       reflect.TypeOf(int8(0)),
+	CandidateInt16:      reflect.TypeOf(int16(0)),
+	CandidateInt32:      reflect.TypeOf(int32(0)),
+	CandidateInt64:      reflect.TypeOf(int64(0)),
+	CandidateBigInt:     reflect.TypeOf(big.Int{}),
+	CandidateFloat32:    reflect.TypeOf(float32(0)),
+	CandidateFloat64:    reflect.TypeOf(float64(0)),
+	CandidateDecimal:    reflect.TypeOf(big.Rat{}),
+	CandidateComplex64:  reflect.TypeOf(complex64(0)),
+	CandidateComplex128: reflect.TypeOf(complex128(0)),
+	CandidateDuration:   reflect.TypeOf(time.Duration(0)),
+	CandidateString:     reflect.TypeOf(""),
+}
+
+// Type returns the Go type of the candidate.
+func (c Candidate) Type() reflect.Type {
+	if c < 0 || c >= numCandidates {
+		return nil
+	}
+	return candidateTypes[c]
+}
+
+// Kind returns the reflect.Kind of the candidate's Go type, e.g.
+// reflect.Struct for CandidateTime.
+func (c Candidate) Kind() reflect.Kind {
+	if t := c.Type(); t != nil {
+		return t.Kind()
+	}
+	return reflect.Invalid
+}
+
+// String returns the candidate's Go type expression, e.g. "int16" or "time.Time".
+func (c Candidate) String() string {
+	if t := c.Type(); t != nil {
+		return t.String()
+	}
+	return "Candidate(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Viable returns, in order of preference, the candidates every value
+// satisfies, or all but a tolerated few do. CandidateString is always viable.
+func (ti *StringTyper) Viable() []Candidate {
+	var viable []Candidate
+	for c := CandidateBool; c < CandidateString; c++ {
+		if ti.ok(c) {
+			viable = append(viable, c)
+		}
+	}
+	return append(viable, CandidateString)
+}
+
+// flag returns the always flag of the candidate.
+func (ti *StringTyper) flag(c Candidate) *bool {
+	switch c {
+	case CandidateBool:
+		return &ti.alwaysBool
+	case CandidateTime:
+		return &ti.alwaysTime
+	case CandidateUint8:
+		return &ti.alwaysUint08
+	case CandidateUint16:
+		return &ti.alwaysUint16
+	case CandidateUint32:
+		return &ti.alwaysUint32
+	case CandidateUint64:
+		return &ti.alwaysUint64
+	case CandidateInt8:
+		return &ti.alwaysInt08
+	case CandidateInt16:
+		return &ti.alwaysInt16
+	case CandidateInt32:
+		return &ti.alwaysInt32
+	case CandidateInt64:
+		return &ti.alwaysInt64
+	case CandidateBigInt:
+		return &ti.alwaysBigInt
+	case CandidateFloat32:
+		return &ti.alwaysFloat32
+	case CandidateFloat64:
+		return &ti.alwaysFloat64
+	case CandidateDecimal:
+		return &ti.alwaysDecimal
+	case CandidateComplex64:
+		return &ti.alwaysComplex64
+	case CandidateComplex128:
+		return &ti.alwaysComplex128
+	case CandidateDuration:
+		return &ti.alwaysDuration
+	}
+	return nil
+}
+
+// FirstError returns the error from the first value that failed the
+// candidate, or nil if none did. Errors from strconv are *strconv.NumError,
+// so errors.Is(err, strconv.ErrRange) tells a value out of the candidate's
+// range from one that is not a number at all (strconv.ErrSyntax).
+func (ti *StringTyper) FirstError(c Candidate) error {
+	if c < 0 || c >= numCandidates {
+		return nil
+	}
+	return ti.rejected[c]
+}
+
+// LastError returns the error from the last value that failed the
+// candidate, or nil if none did; see FirstError.
+func (ti *StringTyper) LastError(c Candidate) error {
+	if c < 0 || c >= numCandidates {
+		return nil
+	}
+	return ti.lastRejected[c]
+}
+
+// reject rules out the candidate for the value being checked, remembering
+// the first and last reasons why.
+func (ti *StringTyper) reject(c Candidate, err error) {
+	*ti.flag(c) = false
+	if ti.rejected[c] == nil {
+		ti.rejected[c] = err
+	}
+	ti.lastRejected[c] = err
+	ti.fail(c, err)
+}
@@ -0,0 +1,26 @@
+package stringtyper
+
+// SetTrimSpace controls whether leading and trailing white space is removed
+// from values before they are checked, so " 42 " is an integer. TrimmedCount
+// reports how many values needed trimming.
+func (ti *StringTyper) SetTrimSpace(trim bool) {
+	ti.trimSpace = trim
+}
+
+// SetCaseFold controls whether bool vocabularies match regardless of case,
+// so "tRuE" is a bool. FoldedCount reports how many values needed folding.
+// Special float values ("NaN", "Inf", "Infinity") match regardless of case
+// anyway, as strconv.ParseFloat accepts them in any case.
+func (ti *StringTyper) SetCaseFold(fold bool) {
+	ti.caseFold = fold
+}
+
+// TrimmedCount returns the number of values that had white space removed.
+func (ti *StringTyper) TrimmedCount() int {
+	return ti.trimmedCount
+}
+
+// FoldedCount returns the number of values that only matched once case was ignored.
+func (ti *StringTyper) FoldedCount() int {
+	return ti.foldedCount
+}
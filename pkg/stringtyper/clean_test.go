@@ -0,0 +1,92 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type CleanColumnTest struct {
+	column       []Column
+	opts         []Option
+	kind         reflect.Kind
+	trimmedCount int
+	foldedCount  int
+	nullCount    int
+}
+
+var testCasesClean = []CleanColumnTest{
+	CleanColumnTest{
+		column: []Column{" 42 ", "7"},
+		kind:   reflect.String,
+	},
+	CleanColumnTest{
+		column:       []Column{" 42 ", "7", "\t-1\n"},
+		opts:         []Option{WithTrimSpace(true)},
+		kind:         reflect.Int8,
+		trimmedCount: 2,
+	},
+	CleanColumnTest{
+		column:       []Column{"TRUE ", "false"},
+		opts:         []Option{WithTrimSpace(true)},
+		kind:         reflect.Bool,
+		trimmedCount: 1,
+	},
+	// Blank values are nulls once trimmed
+	CleanColumnTest{
+		column:       []Column{"1", "   ", "2"},
+		opts:         []Option{WithTrimSpace(true)},
+		kind:         reflect.Uint8,
+		trimmedCount: 1,
+		nullCount:    1,
+	},
+	CleanColumnTest{
+		column: []Column{"tRuE", "False"},
+		kind:   reflect.String,
+	},
+	CleanColumnTest{
+		column:      []Column{"tRuE", "False", "fALSE"},
+		opts:        []Option{WithCaseFold(true)},
+		kind:        reflect.Bool,
+		foldedCount: 2,
+	},
+	CleanColumnTest{
+		column:       []Column{" tRuE", "f"},
+		opts:         []Option{WithTrimSpace(true), WithCaseFold(true)},
+		kind:         reflect.Bool,
+		trimmedCount: 1,
+		foldedCount:  1,
+	},
+	// Case insensitive vocabularies do not need folding
+	CleanColumnTest{
+		column: []Column{"YES", "no"},
+		opts:   []Option{WithCaseFold(true), WithBoolVocabularies(BoolYesNo)},
+		kind:   reflect.Bool,
+	},
+	CleanColumnTest{
+		column: []Column{"nan", "INF", "1.5"},
+		kind:   reflect.Float32,
+	},
+}
+
+func TestCasesClean(t *testing.T) {
+	for _, test := range testCasesClean {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if n := ti.TrimmedCount(); n != test.trimmedCount {
+			t.Error(test.column, "trimmedCount", test.trimmedCount, n)
+		}
+		if n := ti.FoldedCount(); n != test.foldedCount {
+			t.Error(test.column, "foldedCount", test.foldedCount, n)
+		}
+		if n := ti.NullCount(); n != test.nullCount {
+			t.Error(test.column, "nullCount", test.nullCount, n)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package stringtyper
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"strconv"
+)
+
+// checkComplex checks v against the complex candidates. Like the float
+// candidates, complex64 is ruled out by a real or imaginary part too small
+// or too big for a float32. Real numbers are complex too, so a column is
+// only complex when some value has an imaginary part, such as "1+2i".
+func (ti *StringTyper) checkComplex(n *number) {
+	v := n.v
+	c, err := complex(n.f64, 0), n.err64
+	if err != nil {
+		// Not a real number, but it may be complex, e.g. "1+2i"
+		if errors.Is(err, strconv.ErrSyntax) {
+			c, err = strconv.ParseComplex(v, 128)
+		} else {
+			err = &strconv.NumError{Func: "ParseComplex", Num: v, Err: strconv.ErrRange}
+		}
+	}
+	if err != nil {
+		ti.reject(CandidateComplex64, err)
+		ti.reject(CandidateComplex128, err)
+		return
+	}
+
+	if !ti.specialFloats && (cmplx.IsNaN(c) || cmplx.IsInf(c)) {
+		err := &strconv.NumError{Func: "ParseComplex", Num: v, Err: ErrSpecialFloat}
+		ti.reject(CandidateComplex64, err)
+		ti.reject(CandidateComplex128, err)
+		return
+	}
+
+	for _, f := range []float64{real(c), imag(c)} {
+		if abs := math.Abs(f); abs > 0.0 && abs < math.SmallestNonzeroFloat32 {
+			ti.reject(CandidateComplex64, &strconv.NumError{Func: "ParseComplex", Num: v, Err: strconv.ErrRange})
+		}
+	}
+
+	if imag(c) == 0 && n.err64 == nil {
+		if n.err32 != nil {
+			ti.reject(CandidateComplex64, &strconv.NumError{Func: "ParseComplex", Num: v, Err: strconv.ErrRange})
+		}
+	} else if _, err := strconv.ParseComplex(v, 64); err != nil {
+		ti.reject(CandidateComplex64, err)
+	}
+}
@@ -0,0 +1,41 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type ComplexColumnTest struct {
+	column []Column
+	opts   []Option
+	kind   reflect.Kind
+}
+
+var testCasesComplex = []ComplexColumnTest{
+	ComplexColumnTest{column: []Column{"1+2i", "-3.5-0.5i", "2i", "7"}, kind: reflect.Complex64},
+	ComplexColumnTest{column: []Column{"(1+2i)", "", "1e10i"}, kind: reflect.Complex64},
+	// Too small or too big for the float32 parts of a complex64
+	ComplexColumnTest{column: []Column{"1+1e-50i"}, kind: reflect.Complex128},
+	ComplexColumnTest{column: []Column{"1e300+1i"}, kind: reflect.Complex128},
+	ComplexColumnTest{column: []Column{"1+1e400i"}, kind: reflect.String},
+	ComplexColumnTest{column: []Column{"NaN+1i", "1-Infi"}, kind: reflect.Complex64},
+	ComplexColumnTest{column: []Column{"NaN+1i"}, opts: []Option{WithSpecialFloats(false)}, kind: reflect.String},
+	// Real numbers are floats, not complex
+	ComplexColumnTest{column: []Column{"1.5", "-2"}, kind: reflect.Float32},
+	ComplexColumnTest{column: []Column{"1+2i", "x"}, kind: reflect.String},
+	ComplexColumnTest{column: []Column{"1+2i"}, opts: []Option{WithCandidates(CandidateComplex128)}, kind: reflect.Complex128},
+}
+
+func TestCasesComplex(t *testing.T) {
+	for _, test := range testCasesComplex {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k, ti.Result().Rejected)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package stringtyper
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrPrecisionLoss is the error, wrapped in a *strconv.NumError, for decimal
+// numbers a float cannot represent exactly when exact decimals are required.
+var ErrPrecisionLoss = errors.New("value not exactly representable")
+
+// Precision returns the precision of the decimal numbers seen: the most
+// digits before the decimal point plus the most digits after it, so the
+// numbers fit a SQL NUMERIC(Precision(), Scale()) column. Leading zeros are
+// not counted; trailing zeros after the decimal point are. Numbers with an
+// exponent are counted as if written out, e.g. "1.5e3" as "1500".
+func (ti *StringTyper) Precision() int {
+	return ti.maxIntDigits + ti.maxScale
+}
+
+// Scale returns the most digits after the decimal point of the decimal
+// numbers seen.
+func (ti *StringTyper) Scale() int {
+	return ti.maxScale
+}
+
+// SetExactDecimals controls whether decimal numbers a float cannot represent
+// exactly rule out that float: float32 for "1234567.89", both floats for
+// "12345678901234567.89", leaving CandidateDecimal. It is off by default, so
+// that values written with more digits than a float keeps, e.g. a float32
+// printed as a float64, are floats: they take any value they can hold, and
+// PrecisionLoss reports whether some lost digits.
+func (ti *StringTyper) SetExactDecimals(exact bool) {
+	ti.exactDecimals = exact
+}
+
+// PrecisionLoss reports whether a decimal number was seen that the inferred
+// float type, or float64 if the type is not a float, cannot represent
+// exactly, i.e. whose float neither formats back to the same number nor is
+// exactly that number.
+func (ti *StringTyper) PrecisionLoss() bool {
+	if ti.Candidate() == CandidateFloat32 {
+		return ti.precisionLoss || ti.precisionLoss32
+	}
+	return ti.precisionLoss
+}
+
+// checkDecimal checks v against CandidateDecimal and tracks its precision and
+// scale. It returns the significant digits of v and the position of the
+// decimal point in them if v is a decimal number.
+func (ti *StringTyper) checkDecimal(v string) (string, int, bool) {
+	digits, point, ok := parseDecimal(v, ti.intLiteralBase == 0)
+	if !ok {
+		ti.reject(CandidateDecimal, &strconv.NumError{Func: "ParseDecimal", Num: v, Err: strconv.ErrSyntax})
+		return "", 0, false
+	}
+
+	intDigits, scale := point, len(digits)-point
+	if intDigits < 0 {
+		intDigits = 0
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	if intDigits > ti.maxIntDigits {
+		ti.maxIntDigits = intDigits
+	}
+	if scale > ti.maxScale {
+		ti.maxScale = scale
+	}
+	return digits, point, true
+}
+
+// checkPrecisionLoss checks whether the float64 of the decimal number v,
+// with the given significant digits and decimal point, is exactly v.
+func (ti *StringTyper) checkPrecisionLoss(n *number, digits string, point int) {
+	// Once a value lost digits, more only matter to rule out float64
+	if ti.precisionLoss && !(ti.exactDecimals && ti.checking(CandidateFloat64)) {
+		return
+	}
+	exact, ok := n.exactInt()
+	if !ok {
+		exact = exactFloat(digits, point, n.f64, 64)
+	}
+	if exact {
+		return
+	}
+
+	v := n.v
+	ti.precisionLoss = true
+	if ti.exactDecimals {
+		err := &strconv.NumError{Func: "ParseFloat", Num: v, Err: ErrPrecisionLoss}
+		ti.reject(CandidateFloat32, err)
+		ti.rejectFloat64(err)
+	}
+}
+
+// checkPrecisionLoss32 checks whether the float32 of the decimal number v is
+// exactly v. A float32 equal to the float64 is left to checkPrecisionLoss.
+func (ti *StringTyper) checkPrecisionLoss32(n *number, digits string, point int) {
+	if ti.precisionLoss32 && !ti.exactDecimals || !ti.checking(CandidateFloat32) {
+		return
+	}
+	if n.f32 == n.f64 || exactFloat(digits, point, n.f32, 32) {
+		return
+	}
+
+	ti.precisionLoss32 = true
+	if ti.exactDecimals {
+		ti.reject(CandidateFloat32, &strconv.NumError{Func: "ParseFloat", Num: n.v, Err: ErrPrecisionLoss})
+	}
+}
+
+// Smallest normal floats: below them fewer significant digits are exact
+const (
+	minNormalFloat32 = 0x1p-126
+	minNormalFloat64 = 0x1p-1022
+)
+
+// exactFloat reports whether f, parsed from the decimal number with the given
+// significant digits and decimal point, formats back to the same number.
+func exactFloat(digits string, point int, f float64, bitSize int) bool {
+	digits = strings.TrimRight(digits, "0")
+	if len(digits) == 0 {
+		return f == 0
+	}
+
+	// Decimals with up to 6 (float32) or 15 (float64) significant digits
+	// always survive the round trip.
+	abs := math.Abs(f)
+	if bitSize == 32 && len(digits) <= 6 && abs >= minNormalFloat32 {
+		return true
+	}
+	if bitSize == 64 && len(digits) <= 15 && abs >= minNormalFloat64 {
+		return true
+	}
+
+	var buf [32]byte
+	shortest, shortestPoint := appendShortest(buf[:0], abs, bitSize)
+	if len(digits) <= len(shortest) {
+		return point == shortestPoint && string(shortest) == digits
+	}
+
+	// More digits than the shortest formatting: f may still be exactly the
+	// number, e.g. "340282346638528859811704183484516925440" (math.MaxFloat32)
+	want, ok := new(big.Rat).SetString(digits + "e" + strconv.Itoa(point-len(digits)))
+	return ok && want.Cmp(new(big.Rat).SetFloat64(abs)) == 0
+}
+
+// appendShortest appends the significant digits of the shortest formatting
+// of f, which is positive, to buf, and returns them with the position of the
+// decimal point in them, as parseDecimal does.
+func appendShortest(buf []byte, f float64, bitSize int) ([]byte, int) {
+	// d.ddde±XX
+	s := strconv.AppendFloat(buf, f, 'e', -1, bitSize)
+	i := bytes.IndexByte(s, 'e')
+	exp := 0
+	for _, c := range s[i+2:] {
+		exp = exp*10 + int(c-'0')
+	}
+	if s[i+1] == '-' {
+		exp = -exp
+	}
+	digits := s[:1]
+	if i > 1 {
+		digits = append(digits, s[2:i]...)
+	}
+	return digits, exp + 1
+}
+
+// parseDecimal splits v, a decimal number such as "-12.50" or "1.5e-3", into
+// its digits, less leading zeros, and the position of the decimal point in
+// them, e.g. "125" and -2 for "0.00125". With underscores, digits may be
+// separated by underscores as in Go literals, e.g. "1_000.5".
+func parseDecimal(v string, underscores bool) (string, int, bool) {
+	if len(v) > 0 && (v[0] == '+' || v[0] == '-') {
+		v = v[1:]
+	}
+	if strings.IndexByte(v, '_') >= 0 {
+		if !underscores || !underscoresBetweenDigits(v) {
+			return "", 0, false
+		}
+		v = strings.ReplaceAll(v, "_", "")
+	}
+
+	exp := 0
+	if i := strings.IndexAny(v, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.Atoi(v[i+1:]); err != nil {
+			return "", 0, false
+		}
+		v = v[:i]
+	}
+
+	intPart, fracPart := v, ""
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		intPart, fracPart = v[:i], v[i+1:]
+	}
+	if len(intPart)+len(fracPart) == 0 || !isDigits(intPart) || !isDigits(fracPart) {
+		return "", 0, false
+	}
+
+	digits := intPart + fracPart
+	point := len(intPart) + exp
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+		point--
+	}
+	return digits, point, true
+}
+
+// underscoresBetweenDigits reports whether every underscore in v has a digit
+// on each side.
+func underscoresBetweenDigits(v string) bool {
+	for i := 0; i < len(v); i++ {
+		if v[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(v)-1 || !isDigits(v[i-1:i]) || !isDigits(v[i+1:i+2]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
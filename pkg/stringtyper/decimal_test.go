@@ -0,0 +1,184 @@
+package stringtyper
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+type DecimalColumnTest struct {
+	column    []Column
+	opts      []Option
+	precision int
+	scale     int
+}
+
+var testCasesDecimal = []DecimalColumnTest{
+	DecimalColumnTest{column: []Column{"123.45", "-1.5", "0.001"}, precision: 6, scale: 3},
+	DecimalColumnTest{column: []Column{"1.50"}, precision: 3, scale: 2},
+	DecimalColumnTest{column: []Column{"007", "0.0"}, precision: 2, scale: 1},
+	DecimalColumnTest{column: []Column{"1.5e3"}, precision: 4, scale: 0},
+	DecimalColumnTest{column: []Column{"1.5e-3"}, precision: 4, scale: 4},
+	DecimalColumnTest{column: []Column{"12345678901234567.89"}, precision: 19, scale: 2},
+	DecimalColumnTest{column: []Column{".5", "5."}, precision: 2, scale: 1},
+	DecimalColumnTest{column: []Column{"1,234.56"}, opts: []Option{WithLocales(LocaleEN)}, precision: 6, scale: 2},
+	DecimalColumnTest{column: []Column{"1.234,5"}, opts: []Option{WithLocales(LocaleDE)}, precision: 5, scale: 1},
+	DecimalColumnTest{column: []Column{"1_000.5"}, opts: []Option{WithGoIntLiterals(true)}, precision: 5, scale: 1},
+	// Not decimal numbers
+	DecimalColumnTest{column: []Column{"NaN", "Inf", "0x1p-2", "abc", "1.2.3", "1e", "."}},
+	// Underscores only between digits, and only as Go literals
+	DecimalColumnTest{column: []Column{"1_000.5", "_1", "2_"}},
+	DecimalColumnTest{column: []Column{"_1", "2_", "1__0", "1_.5", "1e_5"}, opts: []Option{WithGoIntLiterals(true)}},
+}
+
+func TestCasesDecimal(t *testing.T) {
+	for _, test := range testCasesDecimal {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if ti.Precision() != test.precision || ti.Scale() != test.scale {
+			t.Error(test.column, test.precision, test.scale, ti.Precision(), ti.Scale())
+		}
+	}
+}
+
+type ExactDecimalColumnTest struct {
+	column        []Column
+	opts          []Option
+	candidate     Candidate
+	precisionLoss bool
+}
+
+var testCasesExactDecimal = []ExactDecimalColumnTest{
+	ExactDecimalColumnTest{
+		column:        []Column{"12345678901234567.89", "1.5"},
+		candidate:     CandidateFloat32,
+		precisionLoss: true,
+	},
+	ExactDecimalColumnTest{
+		column:        []Column{"12345678901234567.89", "1.5"},
+		opts:          []Option{WithExactDecimals(true)},
+		candidate:     CandidateDecimal,
+		precisionLoss: true,
+	},
+	// float32 holds 1234567.875
+	ExactDecimalColumnTest{
+		column:        []Column{"1234567.89"},
+		candidate:     CandidateFloat32,
+		precisionLoss: true,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"1234567.89"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat64,
+	},
+	ExactDecimalColumnTest{
+		column:        []Column{"12345678901234567.89", "1e300"},
+		candidate:     CandidateFloat64,
+		precisionLoss: true,
+	},
+	// Written as float64, float32 holds it as well
+	ExactDecimalColumnTest{
+		column:    []Column{"0.10000000149011612"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat32,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"0.1", "123456789.012345", "-0.000001"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat64,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"0.1", "1.5", "-2.25"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat32,
+	},
+	// Exact in float64 but not float32
+	ExactDecimalColumnTest{
+		column:    []Column{"0.1", "1.23456789"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat64,
+	},
+	// Many digits, but exact
+	ExactDecimalColumnTest{
+		column:    []Column{"340282346638528859811704183484516925440", "1.5"},
+		opts:      []Option{WithExactDecimals(true)},
+		candidate: CandidateFloat32,
+	},
+	ExactDecimalColumnTest{
+		column:        []Column{"0.30000000000000004", "0.3000000000000000444"},
+		opts:          []Option{WithExactDecimals(true)},
+		candidate:     CandidateDecimal,
+		precisionLoss: true,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"_1", "_2"},
+		candidate: CandidateString,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"1_000", "1_000.5"},
+		candidate: CandidateString,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"1_000", "1_000.5"},
+		opts:      []Option{WithGoIntLiterals(true)},
+		candidate: CandidateFloat32,
+	},
+	// Out of range for float64
+	ExactDecimalColumnTest{
+		column:    []Column{"1e400", "-2.5"},
+		candidate: CandidateDecimal,
+	},
+	ExactDecimalColumnTest{
+		column:    []Column{"1e400", "NaN"},
+		candidate: CandidateString,
+	},
+}
+
+func TestCasesExactDecimal(t *testing.T) {
+	for _, test := range testCasesExactDecimal {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if c := ti.Candidate(); c != test.candidate {
+			t.Error(test.column, test.candidate, c)
+		}
+		if ti.PrecisionLoss() != test.precisionLoss {
+			t.Error(test.column, "precisionLoss", test.precisionLoss, ti.PrecisionLoss())
+		}
+	}
+}
+
+func TestExactFloat(t *testing.T) {
+	// exactFloat against the definition: the shortest formatting of the
+	// float is the number, or the float is exactly the number
+	values := append(numberValues(), "0.1", "0.10000000149011612", "1234567.89", "12345678901234567.89",
+		"340282346638528859811704183484516925440", "0.30000000000000004", "0.3000000000000000444", "1e-45", "1.4e-45", "5e-324")
+	for _, v := range values {
+		digits, point, ok := parseDecimal(v, false)
+		if !ok {
+			continue
+		}
+		want, _ := new(big.Rat).SetString(v)
+		want.Abs(want)
+		for _, bitSize := range []int{32, 64} {
+			f, err := strconv.ParseFloat(v, bitSize)
+			if err != nil {
+				continue
+			}
+			f = math.Abs(f)
+			shortest, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bitSize))
+			exact := want.Cmp(shortest) == 0 || want.Cmp(new(big.Rat).SetFloat64(f)) == 0
+			if got := exactFloat(digits, point, f, bitSize); got != exact {
+				t.Error(v, bitSize, exact, got)
+			}
+		}
+	}
+}
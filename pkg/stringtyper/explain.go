@@ -0,0 +1,60 @@
+package stringtyper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rejection is a value that failed a candidate.
+type Rejection struct {
+	Value string
+	// Row is the position of the value among all the values checked,
+	// including nulls, starting at 1.
+	Row int
+	// Err is why the value failed; see StringTyper.FirstError.
+	Err error
+}
+
+func (r Rejection) String() string {
+	return fmt.Sprintf("'%s' at row %d", r.Value, r.Row)
+}
+
+// Rejections returns the first values that failed the candidate, up to the
+// limit set by SetFailedValuesLimit.
+func (ti *StringTyper) Rejections(c Candidate) []Rejection {
+	if c < 0 || c >= numCandidates {
+		return nil
+	}
+	return append([]Rejection(nil), ti.rejections[c]...)
+}
+
+// Explain describes, one line per candidate, the values that ruled out each
+// candidate considered, e.g. "int16 rejected by '40000' at row 812", followed
+// by the inferred type.
+func (ti *StringTyper) Explain() string {
+	var b strings.Builder
+	for c := CandidateBool; c < CandidateString; c++ {
+		if ti.disabled[c] || ti.failures[c] == 0 {
+			continue
+		}
+		samples := make([]string, len(ti.rejections[c]))
+		for i, r := range ti.rejections[c] {
+			samples[i] = r.String()
+		}
+		fmt.Fprintf(&b, "%s rejected by ", c)
+		if len(samples) == 0 {
+			fmt.Fprintf(&b, "%d value(s)", ti.failures[c])
+		} else {
+			b.WriteString(strings.Join(samples, ", "))
+			if more := ti.failures[c] - len(samples); more > 0 {
+				fmt.Fprintf(&b, " and %d more", more)
+			}
+		}
+		if ti.ok(c) {
+			b.WriteString(" (tolerated)")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%s inferred from %d values\n", ti.Candidate(), ti.count+ti.nullCount)
+	return b.String()
+}
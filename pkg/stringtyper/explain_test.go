@@ -0,0 +1,103 @@
+package stringtyper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRejections(t *testing.T) {
+	ti := NewStringTyper(WithFailedValuesLimit(2))
+	for _, s := range []string{"1", "NA", "200", "40000", "-5", "70000"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+
+	want := []Rejection{{Value: "40000", Row: 4}, {Value: "70000", Row: 6}}
+	if r := ti.Rejections(CandidateInt16); !equalRejections(r, want) {
+		t.Error("int16", want, r)
+	}
+	want = []Rejection{{Value: "200", Row: 3}, {Value: "40000", Row: 4}}
+	if r := ti.Rejections(CandidateInt8); !equalRejections(r, want) {
+		t.Error("int8", want, r)
+	}
+	for _, r := range ti.Rejections(CandidateInt8) {
+		if !errors.Is(r.Err, strconv.ErrRange) {
+			t.Error(r, r.Err)
+		}
+	}
+	if n := ti.Failures(CandidateInt8); n != 3 {
+		t.Error("int8 failures", n)
+	}
+	if r := ti.Rejections(CandidateInt32); r != nil {
+		t.Error("int32", r)
+	}
+	if r := ti.Rejections(Candidate(-1)); r != nil {
+		t.Error(r)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	ti := NewStringTyper(WithFailedValuesLimit(1))
+	for _, s := range []string{"1", "40000", "-5", "70000"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+
+	e := ti.Explain()
+	for _, line := range []string{
+		"int16 rejected by '40000' at row 2 and 1 more\n",
+		"uint8 rejected by '40000' at row 2 and 2 more\n",
+		"bool rejected by '40000' at row 2\n",
+		"int32 inferred from 4 values\n",
+	} {
+		if !strings.Contains(e, line) {
+			t.Error("missing", line, "in", e)
+		}
+	}
+	if strings.Contains(e, "\nint32 rejected") {
+		t.Error(e)
+	}
+
+	ti = NewStringTyper(WithFailedValuesLimit(0), WithTolerance(1))
+	for _, s := range []string{"1", "x"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if e := ti.Explain(); !strings.Contains(e, "uint8 rejected by 1 value(s) (tolerated)\n") {
+		t.Error(e)
+	}
+}
+
+func TestExplainReadme(t *testing.T) {
+	// The sample in the README
+	ti := NewStringTyper()
+	for _, s := range []string{"1", "2", "300", "4", "5", "6", "-200", "8", "9", "10"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+
+	want := `bool rejected by '2' at row 2
+time.Time rejected by '1' at row 1
+uint8 rejected by '300' at row 3, '-200' at row 7
+uint16 rejected by '-200' at row 7
+uint32 rejected by '-200' at row 7
+uint64 rejected by '-200' at row 7
+int8 rejected by '300' at row 3, '-200' at row 7
+time.Duration rejected by '1' at row 1
+int16 inferred from 10 values
+`
+	if e := ti.Explain(); e != want {
+		t.Error(e)
+	}
+}
+
+// equalRejections compares the values and rows of the rejections.
+func equalRejections(a, b []Rejection) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Value != b[i].Value || a[i].Row != b[i].Row {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package stringtyper
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrSpecialFloat is the error, wrapped in a *strconv.NumError, for NaN and
+// infinite values when special floats are not allowed.
+var ErrSpecialFloat = errors.New("special float value not allowed")
+
+// SetSpecialFloats controls whether the float candidates accept NaN and
+// infinite values ("NaN", "Inf", "+Infinity", ...). They are allowed by
+// default. Either way they are counted, and MinFloat, MaxFloat and
+// SmallestFloat ignore them.
+func (ti *StringTyper) SetSpecialFloats(allow bool) {
+	ti.specialFloats = allow
+}
+
+// NaNCount returns the number of NaN values.
+func (ti *StringTyper) NaNCount() int {
+	return ti.nanCount
+}
+
+// PosInfCount returns the number of positive infinite values.
+func (ti *StringTyper) PosInfCount() int {
+	return ti.posInfCount
+}
+
+// NegInfCount returns the number of negative infinite values.
+func (ti *StringTyper) NegInfCount() int {
+	return ti.negInfCount
+}
+
+// checkSpecialFloat counts v if it is NaN or infinite, and reports whether it is.
+func (ti *StringTyper) checkSpecialFloat(v float64) bool {
+	switch {
+	case math.IsNaN(v):
+		ti.nanCount++
+	case math.IsInf(v, 1):
+		ti.posInfCount++
+	case math.IsInf(v, -1):
+		ti.negInfCount++
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package stringtyper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type SpecialFloatColumnTest struct {
+	column      []Column
+	opts        []Option
+	kind        reflect.Kind
+	nanCount    int
+	posInfCount int
+	negInfCount int
+	minFloat    *float64
+	maxFloat    *float64
+}
+
+var testCasesSpecialFloat = []SpecialFloatColumnTest{
+	SpecialFloatColumnTest{
+		column:      []Column{"nan", "inf", "-Infinity", "+Inf", "NaN"},
+		kind:        reflect.Float32,
+		nanCount:    2,
+		posInfCount: 2,
+		negInfCount: 1,
+	},
+	SpecialFloatColumnTest{
+		column:      []Column{"NaN", "1.5", "-2.5", "Inf"},
+		kind:        reflect.Float32,
+		nanCount:    1,
+		posInfCount: 1,
+		minFloat:    Float64(-2.5),
+		maxFloat:    Float64(1.5),
+	},
+	SpecialFloatColumnTest{
+		column:      []Column{"NaN", "1.5", "-Inf"},
+		opts:        []Option{WithSpecialFloats(false)},
+		kind:        reflect.String,
+		nanCount:    1,
+		negInfCount: 1,
+	},
+	SpecialFloatColumnTest{
+		column:   []Column{"1.5", "-2"},
+		opts:     []Option{WithSpecialFloats(false)},
+		kind:     reflect.Float32,
+		minFloat: Float64(-2),
+		maxFloat: Float64(1.5),
+	},
+	// Overflow is not infinity, and is a decimal
+	SpecialFloatColumnTest{
+		column: []Column{"1e400"},
+		kind:   reflect.Struct,
+	},
+}
+
+func TestCasesSpecialFloat(t *testing.T) {
+	for _, test := range testCasesSpecialFloat {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if ti.NaNCount() != test.nanCount || ti.PosInfCount() != test.posInfCount || ti.NegInfCount() != test.negInfCount {
+			t.Error(test.column, "counts", test.nanCount, test.posInfCount, test.negInfCount, ti.NaNCount(), ti.PosInfCount(), ti.NegInfCount())
+		}
+		if test.minFloat != nil && (ti.MinFloat == nil || *ti.MinFloat != *test.minFloat) {
+			t.Error(test.column, "minFloat", *test.minFloat, ti.MinFloat)
+		}
+		if test.maxFloat != nil && (ti.MaxFloat == nil || *ti.MaxFloat != *test.maxFloat) {
+			t.Error(test.column, "maxFloat", *test.maxFloat, ti.MaxFloat)
+		}
+		if test.minFloat == nil && ti.MinFloat != nil {
+			t.Error(test.column, "minFloat", *ti.MinFloat)
+		}
+	}
+}
+
+func TestSpecialFloatRejection(t *testing.T) {
+	ti := NewStringTyper(WithSpecialFloats(false))
+	ti.CheckFieldTypeAndLength("NaN")
+	if !errors.Is(ti.errFloat64, ErrSpecialFloat) {
+		t.Fatal(ti.errFloat64)
+	}
+}
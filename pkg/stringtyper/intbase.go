@@ -0,0 +1,67 @@
+package stringtyper
+
+import (
+	"strings"
+)
+
+// SetGoIntLiterals controls whether integers may be written as Go integer
+// literals, e.g. "0xFF", "0o17", "0b1010" or "1_000_000", by parsing them with
+// base 0. Note that with Go literals a leading zero means octal, so "010" is 8
+// and "08" is not an integer.
+func (ti *StringTyper) SetGoIntLiterals(accept bool) {
+	if accept {
+		ti.intLiteralBase = 0
+	} else {
+		ti.intLiteralBase = 10
+	}
+}
+
+// IntBase returns the base of the integers seen: 2, 8, 10 or 16. It returns 0
+// if the integers were written in more than one base, or with underscores, so
+// they need to be parsed with base 0 (as Go integer literals). Other than for
+// 10, the prefix ("0x", "0o", "0b" or "0") is part of the value, so a parser
+// needs to use base 0 or strip it.
+func (ti *StringTyper) IntBase() int {
+	if ti.mixedIntBase {
+		return 0
+	}
+	if ti.intBase == 0 {
+		return 10
+	}
+	return ti.intBase
+}
+
+func (ti *StringTyper) checkIntBase(v string) {
+	base := 10
+	if ti.intLiteralBase == 0 {
+		base = intLiteralBase(v)
+		if strings.IndexByte(v, '_') >= 0 {
+			ti.mixedIntBase = true
+		}
+	}
+	if ti.intBase == 0 {
+		ti.intBase = base
+	} else if ti.intBase != base {
+		ti.mixedIntBase = true
+	}
+}
+
+// intLiteralBase returns the base of v, an integer literal, from its prefix.
+func intLiteralBase(v string) int {
+	if len(v) > 0 && (v[0] == '+' || v[0] == '-') {
+		v = v[1:]
+	}
+	if len(v) < 2 || v[0] != '0' {
+		return 10
+	}
+	switch v[1] {
+	case 'x', 'X':
+		return 16
+	case 'o', 'O':
+		return 8
+	case 'b', 'B':
+		return 2
+	}
+	// Leading zero
+	return 8
+}
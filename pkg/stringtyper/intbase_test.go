@@ -0,0 +1,108 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type IntBaseColumnTest struct {
+	column     []Column
+	goLiterals bool
+	kind       reflect.Kind
+	intBase    int
+	minInt     *int64
+	maxUint    *uint64
+}
+
+var testCasesIntBase = []IntBaseColumnTest{
+	IntBaseColumnTest{
+		column:     []Column{"0xFF", "0x10", "0X0"},
+		goLiterals: true,
+		kind:       reflect.Uint8,
+		intBase:    16,
+		maxUint:    Uint64(255),
+	},
+	IntBaseColumnTest{
+		column:     []Column{"0o17", "-0o10"},
+		goLiterals: true,
+		kind:       reflect.Int8,
+		intBase:    8,
+		minInt:     Int64(-8),
+	},
+	IntBaseColumnTest{
+		column:     []Column{"0b1010", "0B1"},
+		goLiterals: true,
+		kind:       reflect.Uint8,
+		intBase:    2,
+		maxUint:    Uint64(10),
+	},
+	IntBaseColumnTest{
+		column:     []Column{"1_000_000", "2"},
+		goLiterals: true,
+		kind:       reflect.Uint32,
+		intBase:    0,
+		maxUint:    Uint64(1000000),
+	},
+	IntBaseColumnTest{
+		column:     []Column{"0xFF", "12"},
+		goLiterals: true,
+		kind:       reflect.Uint8,
+		intBase:    0,
+	},
+	// Leading zero is octal
+	IntBaseColumnTest{
+		column:     []Column{"010", "-07"},
+		goLiterals: true,
+		kind:       reflect.Int8,
+		intBase:    8,
+		minInt:     Int64(-7),
+	},
+	IntBaseColumnTest{
+		column:     []Column{"12", "300"},
+		goLiterals: true,
+		kind:       reflect.Uint16,
+		intBase:    10,
+	},
+	// Without Go literals
+	IntBaseColumnTest{
+		column:  []Column{"010", "12"},
+		kind:    reflect.Uint8,
+		intBase: 10,
+		maxUint: Uint64(12),
+	},
+	IntBaseColumnTest{
+		column:  []Column{"0xFF", "0x10"},
+		kind:    reflect.String,
+		intBase: 10,
+	},
+	// Underscores are only accepted with Go literals, though strconv.ParseFloat takes them
+	IntBaseColumnTest{
+		column:  []Column{"1_000_000"},
+		kind:    reflect.String,
+		intBase: 10,
+	},
+}
+
+func TestCasesIntBase(t *testing.T) {
+	for _, test := range testCasesIntBase {
+		ti := NewStringTyper()
+		ti.SetGoIntLiterals(test.goLiterals)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if b := ti.IntBase(); b != test.intBase {
+			t.Error(test.column, "intBase", test.intBase, b)
+		}
+		if test.minInt != nil && (ti.MinInt == nil || *ti.MinInt != *test.minInt) {
+			t.Error(test.column, "minInt", *test.minInt, ti.MinInt)
+		}
+		if test.maxUint != nil && (ti.MaxUint == nil || *ti.MaxUint != *test.maxUint) {
+			t.Error(test.column, "maxUint", *test.maxUint, ti.MaxUint)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package stringtyper
+
+import (
+	"unicode/utf8"
+)
+
+// Lengths are of the values that are not null. Byte lengths are len(v); rune
+// lengths count UTF-8 encoded runes, so size fixed-width buffers with the
+// former and character columns (e.g. VARCHAR) with the latter.
+
+// MaxLength returns the length in bytes of the longest value.
+func (ti *StringTyper) MaxLength() int {
+	return ti.maxLength
+}
+
+// MinLength returns the length in bytes of the shortest value.
+func (ti *StringTyper) MinLength() int {
+	return ti.minLength
+}
+
+// MeanLength returns the mean length in bytes of the values.
+func (ti *StringTyper) MeanLength() float64 {
+	if ti.count == 0 {
+		return 0
+	}
+	return float64(ti.totalLength) / float64(ti.count)
+}
+
+// MaxRuneLength returns the length in runes of the longest value.
+func (ti *StringTyper) MaxRuneLength() int {
+	return ti.maxRuneLength
+}
+
+// MinRuneLength returns the length in runes of the shortest value.
+func (ti *StringTyper) MinRuneLength() int {
+	return ti.minRuneLength
+}
+
+// MeanRuneLength returns the mean length in runes of the values.
+func (ti *StringTyper) MeanRuneLength() float64 {
+	if ti.count == 0 {
+		return 0
+	}
+	return float64(ti.totalRuneLength) / float64(ti.count)
+}
+
+func (ti *StringTyper) checkLength(v string) {
+	l := len(v)
+	r := utf8.RuneCountInString(v)
+
+	// First value
+	if ti.count == 1 {
+		ti.minLength = l
+		ti.minRuneLength = r
+	}
+
+	if ti.maxLength < l {
+		ti.maxLength = l
+	}
+	if ti.minLength > l {
+		ti.minLength = l
+	}
+	ti.totalLength += l
+
+	if ti.maxRuneLength < r {
+		ti.maxRuneLength = r
+	}
+	if ti.minRuneLength > r {
+		ti.minRuneLength = r
+	}
+	ti.totalRuneLength += r
+}
@@ -0,0 +1,71 @@
+package stringtyper
+
+import (
+	"testing"
+)
+
+type LengthColumnTest struct {
+	column         []Column
+	maxLength      int
+	minLength      int
+	meanLength     float64
+	maxRuneLength  int
+	minRuneLength  int
+	meanRuneLength float64
+}
+
+var testCasesLength = []LengthColumnTest{
+	LengthColumnTest{
+		column:         []Column{"1", "22", "333"},
+		maxLength:      3,
+		minLength:      1,
+		meanLength:     2,
+		maxRuneLength:  3,
+		minRuneLength:  1,
+		meanRuneLength: 2,
+	},
+	// Nulls are not counted
+	LengthColumnTest{
+		column:         []Column{"NULL", "22", "", "4444"},
+		maxLength:      4,
+		minLength:      2,
+		meanLength:     3,
+		maxRuneLength:  4,
+		minRuneLength:  2,
+		meanRuneLength: 3,
+	},
+	LengthColumnTest{
+		column:         []Column{"héllo", "日本"},
+		maxLength:      6,
+		minLength:      6,
+		meanLength:     6,
+		maxRuneLength:  5,
+		minRuneLength:  2,
+		meanRuneLength: 3.5,
+	},
+	LengthColumnTest{
+		column: []Column{"", ""},
+	},
+}
+
+func TestCasesLength(t *testing.T) {
+	for _, test := range testCasesLength {
+		ti := NewStringTyper()
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if ti.MaxLength() != test.maxLength || ti.MinLength() != test.minLength || ti.MeanLength() != test.meanLength {
+			t.Error(test.column, "bytes", test.maxLength, test.minLength, test.meanLength, ti.MaxLength(), ti.MinLength(), ti.MeanLength())
+		}
+		if ti.MaxRuneLength() != test.maxRuneLength || ti.MinRuneLength() != test.minRuneLength || ti.MeanRuneLength() != test.meanRuneLength {
+			t.Error(test.column, "runes", test.maxRuneLength, test.minRuneLength, test.meanRuneLength, ti.MaxRuneLength(), ti.MinRuneLength(), ti.MeanRuneLength())
+		}
+
+		r := ti.Result()
+		if r.MaxLength != test.maxLength || r.MinRuneLength != test.minRuneLength || r.MeanRuneLength != test.meanRuneLength {
+			t.Error(test.column, "result", r)
+		}
+	}
+}
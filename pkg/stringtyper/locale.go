@@ -0,0 +1,199 @@
+package stringtyper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Locale describes how numbers are written: the characters that may group
+// the digits of the integer part in threes, and the decimal separator.
+type Locale struct {
+	Name     string
+	Grouping string
+	Decimal  rune
+}
+
+var (
+	LocaleEN = Locale{Name: "en", Grouping: ",", Decimal: '.'}
+	LocaleDE = Locale{Name: "de", Grouping: ".", Decimal: ','}
+	// Space, no-break space and narrow no-break space
+	LocaleFR = Locale{Name: "fr", Grouping: " \u00a0\u202f", Decimal: ','}
+)
+
+// ErrNoLocale is the error, wrapped in a *strconv.NumError, for values not
+// written in any of the locales set by SetLocales.
+var ErrNoLocale = errors.New("not a number in any locale")
+
+// SetLocales sets the locales numbers may be written in, in order of
+// preference. A locale is ruled out by the first value not written in it.
+// Numbers are checked as written in every locale not ruled out, and the type
+// and ranges are those of the first of them. Once every locale has been
+// ruled out no value is a number. With no locales (the default), numbers are
+// parsed as they are.
+func (ti *StringTyper) SetLocales(locales ...Locale) {
+	ti.locales = append([]Locale(nil), locales...)
+	ti.localeTypers = nil
+	ti.alwaysLocale = make([]bool, len(locales))
+	for i := range ti.alwaysLocale {
+		ti.alwaysLocale[i] = true
+	}
+}
+
+// Locale returns the name of the first locale every value was written in, or
+// "" if there is none.
+func (ti *StringTyper) Locale() string {
+	if i := ti.localeIndex(); i >= 0 {
+		return ti.locales[i].Name
+	}
+	return ""
+}
+
+func (ti *StringTyper) localeIndex() int {
+	for i, ok := range ti.alwaysLocale {
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkLocales checks v against the numeric candidates as written in each
+// locale, and rules out the locales v is not written in. The numbers of ti
+// are those of the first remaining locale, or of the first locale once all
+// are ruled out.
+func (ti *StringTyper) checkLocales(v string) {
+	if ti.localeTypers == nil {
+		ti.localeTypers = ti.newLocaleTypers()
+	}
+
+	found := false
+	normalized := make([]string, len(ti.locales))
+	written := make([]bool, len(ti.locales))
+	for i, l := range ti.locales {
+		normalized[i], written[i] = l.normalize(v)
+		found = found || written[i] && ti.alwaysLocale[i]
+	}
+
+	err := &strconv.NumError{Func: "ParseLocale", Num: v, Err: ErrNoLocale}
+	for i := range ti.locales {
+		sub := ti.syncLocaleTyper(i)
+		if written[i] {
+			sub.checkNumber(normalized[i])
+			continue
+		}
+		sub.rejectNumber(err)
+		// With a tolerance, a value written in no locale, e.g. "x,y", is a
+		// failure of the numbers and rules none of the locales out
+		if found || !ti.tolerant() {
+			ti.alwaysLocale[i] = false
+		}
+	}
+	ti.useNumbers(ti.localeTyper())
+}
+
+// localeTyper returns the StringTyper of the locale the numbers are read in.
+func (ti *StringTyper) localeTyper() *StringTyper {
+	if i := ti.localeIndex(); i >= 0 {
+		return ti.localeTypers[i]
+	}
+	return ti.localeTypers[0]
+}
+
+// newLocaleTypers returns a StringTyper per locale, configured like ti,
+// which must not have checked any numbers yet.
+func (ti *StringTyper) newLocaleTypers() []*StringTyper {
+	typers := make([]*StringTyper, len(ti.locales))
+	for i := range typers {
+		sub := *ti
+		sub.locales, sub.alwaysLocale, sub.localeTypers = nil, nil, nil
+		typers[i] = &sub
+	}
+	return typers
+}
+
+// syncLocaleTyper returns the StringTyper of locale i, at the same value as ti.
+func (ti *StringTyper) syncLocaleTyper(i int) *StringTyper {
+	sub := ti.localeTypers[i]
+	sub.count, sub.nullCount, sub.value = ti.count, ti.nullCount, ti.value
+	return sub
+}
+
+// useNumbers makes what sub, the StringTyper of a locale, saw of the values
+// as numbers what ti saw.
+func (ti *StringTyper) useNumbers(sub *StringTyper) {
+	for c := CandidateUint8; c <= CandidateComplex128; c++ {
+		*ti.flag(c) = *sub.flag(c)
+		ti.rejected[c] = sub.rejected[c]
+		ti.lastRejected[c] = sub.lastRejected[c]
+		ti.failures[c] = sub.failures[c]
+		ti.failedAt[c] = sub.failedAt[c]
+		// Appending to the rejections of ti must not change those of sub
+		n := len(sub.rejections[c])
+		ti.rejections[c] = sub.rejections[c][:n:n]
+	}
+
+	ti.MinUint, ti.MaxUint = copyUint64(sub.MinUint), copyUint64(sub.MaxUint)
+	ti.MinInt, ti.MaxInt = copyInt64(sub.MinInt), copyInt64(sub.MaxInt)
+	ti.MinFloat, ti.MaxFloat = copyFloat64(sub.MinFloat), copyFloat64(sub.MaxFloat)
+	ti.SmallestFloat = copyFloat64(sub.SmallestFloat)
+	ti.errFloat64 = sub.errFloat64
+	ti.intBase, ti.mixedIntBase, ti.hugeIntLoss = sub.intBase, sub.mixedIntBase, sub.hugeIntLoss
+	ti.nanCount, ti.posInfCount, ti.negInfCount = sub.nanCount, sub.posInfCount, sub.negInfCount
+	ti.maxIntDigits, ti.maxScale = sub.maxIntDigits, sub.maxScale
+	ti.precisionLoss, ti.precisionLoss32 = sub.precisionLoss, sub.precisionLoss32
+}
+
+// normalize removes the grouping characters from v and replaces its decimal
+// separator with '.'. It returns false if v uses the separators in a way the
+// locale does not allow, e.g. "1,5" for LocaleEN.
+func (l Locale) normalize(v string) (string, bool) {
+	separators := ".," + l.Grouping + string(l.Decimal)
+	if !strings.ContainsAny(v, separators) {
+		return v, true
+	}
+
+	var b strings.Builder
+	i := 0
+	if i < len(v) && (v[i] == '+' || v[i] == '-') {
+		b.WriteByte(v[i])
+		i++
+	}
+
+	// Integer part: the first group is 1-3 digits, the rest exactly 3
+	grouped := false
+	digits := 0
+integer:
+	for i < len(v) {
+		r, size := utf8.DecodeRuneInString(v[i:])
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+		case strings.ContainsRune(l.Grouping, r):
+			if grouped && digits != 3 || !grouped && (digits == 0 || digits > 3) {
+				return "", false
+			}
+			grouped = true
+			digits = 0
+		default:
+			break integer
+		}
+		i += size
+	}
+	if grouped && digits != 3 {
+		return "", false
+	}
+
+	rest := v[i:]
+	if r, size := utf8.DecodeRuneInString(rest); size > 0 && r == l.Decimal {
+		b.WriteByte('.')
+		rest = rest[size:]
+	}
+	if strings.ContainsAny(rest, separators) {
+		return "", false
+	}
+	b.WriteString(rest)
+	return b.String(), true
+}
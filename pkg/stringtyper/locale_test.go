@@ -0,0 +1,134 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type LocaleColumnTest struct {
+	column   []Column
+	locales  []Locale
+	kind     reflect.Kind
+	locale   string
+	maxUint  *uint64
+	maxFloat *float64
+	// noUint is true if no value is an unsigned integer in the locale
+	noUint bool
+}
+
+var testCasesLocale = []LocaleColumnTest{
+	LocaleColumnTest{
+		column:  []Column{"1,234,567", "12", "999,999"},
+		locales: []Locale{LocaleEN},
+		kind:    reflect.Uint32,
+		locale:  "en",
+		maxUint: Uint64(1234567),
+	},
+	LocaleColumnTest{
+		column:   []Column{"1.234.567,89", "-12,5"},
+		locales:  []Locale{LocaleDE},
+		kind:     reflect.Float32,
+		locale:   "de",
+		maxFloat: Float64(1234567.89),
+	},
+	LocaleColumnTest{
+		column:   []Column{"1 234,5", "2 000", "7"},
+		locales:  []Locale{LocaleFR},
+		kind:     reflect.Float32,
+		locale:   "fr",
+		maxFloat: Float64(2000),
+	},
+	// Both en and de are possible for "1.234": the first wins
+	LocaleColumnTest{
+		column:   []Column{"1.234", "5"},
+		locales:  []Locale{LocaleEN, LocaleDE},
+		kind:     reflect.Float32,
+		locale:   "en",
+		maxFloat: Float64(5),
+	},
+	LocaleColumnTest{
+		column:  []Column{"1.234", "5"},
+		locales: []Locale{LocaleDE, LocaleEN},
+		kind:    reflect.Uint16,
+		locale:  "de",
+		maxUint: Uint64(1234),
+	},
+	// Values seen before en is ruled out are read as de too
+	LocaleColumnTest{
+		column:   []Column{"1,234", "1,5"},
+		locales:  []Locale{LocaleEN, LocaleDE},
+		kind:     reflect.Float32,
+		locale:   "de",
+		maxFloat: Float64(1.5),
+		noUint:   true,
+	},
+	// "1,5" is not en
+	LocaleColumnTest{
+		column:   []Column{"1,5", "1.234,5"},
+		locales:  []Locale{LocaleEN, LocaleDE},
+		kind:     reflect.Float32,
+		locale:   "de",
+		maxFloat: Float64(1234.5),
+	},
+	LocaleColumnTest{
+		column:  []Column{"1,5", "1.5"},
+		locales: []Locale{LocaleEN, LocaleDE},
+		kind:    reflect.String,
+		locale:  "",
+	},
+	// Bad grouping
+	LocaleColumnTest{
+		column:  []Column{"12,34"},
+		locales: []Locale{LocaleEN},
+		kind:    reflect.String,
+	},
+	LocaleColumnTest{
+		column:  []Column{"1234,567"},
+		locales: []Locale{LocaleEN},
+		kind:    reflect.String,
+	},
+	LocaleColumnTest{
+		column:  []Column{",123"},
+		locales: []Locale{LocaleEN},
+		kind:    reflect.String,
+	},
+	// Custom
+	LocaleColumnTest{
+		column:  []Column{"1'234'567.5"},
+		locales: []Locale{Locale{Name: "ch", Grouping: "'", Decimal: '.'}},
+		kind:    reflect.Float32,
+		locale:  "ch",
+	},
+	// No locales: as before
+	LocaleColumnTest{
+		column: []Column{"1,234"},
+		kind:   reflect.String,
+	},
+}
+
+func TestCasesLocale(t *testing.T) {
+	for _, test := range testCasesLocale {
+		ti := NewStringTyper()
+		ti.SetLocales(test.locales...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if l := ti.Locale(); l != test.locale {
+			t.Error(test.column, "locale", test.locale, l)
+		}
+		if test.maxUint != nil && (ti.MaxUint == nil || *ti.MaxUint != *test.maxUint) {
+			t.Error(test.column, "maxUint", *test.maxUint, ti.MaxUint)
+		}
+		if test.maxFloat != nil && (ti.MaxFloat == nil || *ti.MaxFloat != *test.maxFloat) {
+			t.Error(test.column, "maxFloat", *test.maxFloat, ti.MaxFloat)
+		}
+		if test.noUint && ti.MaxUint != nil {
+			t.Error(test.column, "maxUint", *ti.MaxUint)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package stringtyper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Merge adds what other has seen to ti, as if ti had gone on to check the
+// values other checked, so inference over shards of a column gives the same
+// type as a single pass over it. Both must be configured the same way. other
+// is not changed.
+func (ti *StringTyper) Merge(other *StringTyper) error {
+	if len(ti.timeLayouts) != len(other.timeLayouts) ||
+		len(ti.boolVocabularies) != len(other.boolVocabularies) ||
+		len(ti.locales) != len(other.locales) {
+		return errors.New("cannot merge StringTypers configured differently")
+	}
+	if other.count+other.nullCount == 0 {
+		return nil
+	}
+	ti.mergeLocaleTypers(other)
+
+	ti.mergeFailures(other)
+	if other.errFloat64 != nil && ti.numeric() {
+		ti.errFloat64 = other.errFloat64
+	}
+	for c := CandidateBool; c < CandidateString; c++ {
+		*ti.flag(c) = *ti.flag(c) && *other.flag(c)
+	}
+
+	ti.mergeLengths(other)
+	ti.nullCount += other.nullCount
+	ti.count += other.count
+	ti.trimmedCount += other.trimmedCount
+	ti.foldedCount += other.foldedCount
+	ti.nanCount += other.nanCount
+	ti.posInfCount += other.posInfCount
+	ti.negInfCount += other.negInfCount
+
+	ti.mergeIntRanges(other)
+	if other.intBase != 0 {
+		if ti.intBase == 0 {
+			ti.intBase = other.intBase
+		} else if ti.intBase != other.intBase {
+			ti.mixedIntBase = true
+		}
+	}
+	ti.mixedIntBase = ti.mixedIntBase || other.mixedIntBase
+	ti.hugeIntLoss = ti.hugeIntLoss || other.hugeIntLoss
+
+	ti.mergeFloatRanges(other)
+	if other.maxIntDigits > ti.maxIntDigits {
+		ti.maxIntDigits = other.maxIntDigits
+	}
+	if other.maxScale > ti.maxScale {
+		ti.maxScale = other.maxScale
+	}
+	ti.precisionLoss = ti.precisionLoss || other.precisionLoss
+	ti.precisionLoss32 = ti.precisionLoss32 || other.precisionLoss32
+
+	ti.mergeTimes(other)
+	ti.mergeDurations(other)
+
+	// Every value must come from the same bool vocabulary and locale
+	found := false
+	for i := range ti.alwaysBoolVocabulary {
+		ti.alwaysBoolVocabulary[i] = ti.alwaysBoolVocabulary[i] && other.alwaysBoolVocabulary[i]
+		found = found || ti.alwaysBoolVocabulary[i]
+	}
+	ti.alwaysBool = ti.alwaysBool && found
+	for i := range ti.alwaysLocale {
+		ti.alwaysLocale[i] = ti.alwaysLocale[i] && other.alwaysLocale[i]
+	}
+
+	if ti.localeTypers != nil {
+		ti.useNumbers(ti.localeTyper())
+	}
+	if !ti.numeric() {
+		ti.clearNumbers()
+	}
+	ti.value = other.value
+	return nil
+}
+
+// mergeFailures adds the failures of other, whose rows follow those of ti.
+// Without a tolerance, bool, time and duration are only checked up to their
+// first failure, and numbers only until ti stopped parsing them, so the
+// failures of other after that are not counted.
+func (ti *StringTyper) mergeFailures(other *StringTyper) {
+	rows := ti.count + ti.nullCount
+	numeric := ti.numeric()
+	for c := CandidateBool; c < CandidateString; c++ {
+		if other.failures[c] == 0 {
+			continue
+		}
+		if c < CandidateUint8 || c > CandidateComplex128 {
+			if !ti.checking(c) {
+				continue
+			}
+		} else if !numeric {
+			continue
+		}
+
+		ti.failures[c] += other.failures[c]
+		for _, r := range other.rejections[c] {
+			if len(ti.rejections[c]) >= ti.failedValuesLimit {
+				break
+			}
+			r.Row += rows
+			ti.rejections[c] = append(ti.rejections[c], r)
+		}
+		if ti.rejected[c] == nil {
+			ti.rejected[c] = other.rejected[c]
+		}
+		ti.lastRejected[c] = other.lastRejected[c]
+	}
+}
+
+// mergeLocaleTypers merges the StringTypers of each locale, before ti is
+// merged.
+func (ti *StringTyper) mergeLocaleTypers(other *StringTyper) {
+	if other.localeTypers == nil {
+		return
+	}
+	if ti.localeTypers == nil {
+		ti.localeTypers = ti.newLocaleTypers()
+	}
+	for i, sub := range other.localeTypers {
+		ti.syncLocaleTyper(i).Merge(sub)
+	}
+}
+
+func (ti *StringTyper) mergeLengths(other *StringTyper) {
+	if other.count == 0 {
+		return
+	}
+	if ti.count == 0 || other.minLength < ti.minLength {
+		ti.minLength = other.minLength
+	}
+	if ti.count == 0 || other.minRuneLength < ti.minRuneLength {
+		ti.minRuneLength = other.minRuneLength
+	}
+	if other.maxLength > ti.maxLength {
+		ti.maxLength = other.maxLength
+	}
+	if other.maxRuneLength > ti.maxRuneLength {
+		ti.maxRuneLength = other.maxRuneLength
+	}
+	ti.totalLength += other.totalLength
+	ti.totalRuneLength += other.totalRuneLength
+}
+
+func (ti *StringTyper) mergeIntRanges(other *StringTyper) {
+	if other.MinUint != nil {
+		ti.checkUint(*other.MinUint)
+		ti.checkUint(*other.MaxUint)
+	}
+	if other.MinInt != nil {
+		ti.checkInt(*other.MinInt)
+		ti.checkInt(*other.MaxInt)
+	}
+}
+
+func (ti *StringTyper) mergeFloatRanges(other *StringTyper) {
+	if !ti.alwaysFloat64 && !ti.tolerant() {
+		ti.MinFloat = nil
+		ti.MaxFloat = nil
+		ti.SmallestFloat = nil
+		return
+	}
+	if other.MinFloat == nil {
+		return
+	}
+
+	// SmallestFloat is an absolute value, so is not checked like the others
+	smallest := ti.SmallestFloat
+	ti.checkFloat(*other.MinFloat)
+	ti.checkFloat(*other.MaxFloat)
+	ti.SmallestFloat = smallest
+	if ti.SmallestFloat == nil {
+		ti.SmallestFloat = new(float64)
+		*ti.SmallestFloat = *other.SmallestFloat
+	} else if v := *other.SmallestFloat; v > 0 && (*ti.SmallestFloat == 0 || v < *ti.SmallestFloat) {
+		*ti.SmallestFloat = v
+	}
+}
+
+func (ti *StringTyper) mergeTimes(other *StringTyper) {
+	found := false
+	for i := range ti.alwaysTimeLayout {
+		ti.alwaysTimeLayout[i] = ti.alwaysTimeLayout[i] && other.alwaysTimeLayout[i]
+		found = found || ti.alwaysTimeLayout[i]
+
+		if t := other.minTimes[i]; t != nil && (ti.minTimes[i] == nil || t.Before(*ti.minTimes[i])) {
+			ti.minTimes[i] = t
+		}
+		if t := other.maxTimes[i]; t != nil && (ti.maxTimes[i] == nil || t.After(*ti.maxTimes[i])) {
+			ti.maxTimes[i] = t
+		}
+	}
+	ti.alwaysTime = ti.alwaysTime && found
+	ti.setTimeRange()
+}
+
+func (ti *StringTyper) mergeDurations(other *StringTyper) {
+	if !ti.alwaysDuration && !ti.tolerant() {
+		ti.MinDuration = nil
+		ti.MaxDuration = nil
+		return
+	}
+	if d := other.MinDuration; d != nil && (ti.MinDuration == nil || *d < *ti.MinDuration) {
+		ti.MinDuration = new(time.Duration)
+		*ti.MinDuration = *d
+	}
+	if d := other.MaxDuration; d != nil && (ti.MaxDuration == nil || *d > *ti.MaxDuration) {
+		ti.MaxDuration = new(time.Duration)
+		*ti.MaxDuration = *d
+	}
+}
+
+// Merge merges each StringTyper of other into the one for the same column.
+func (tim StringTypers) Merge(other StringTypers) error {
+	if len(other) != len(tim) {
+		return fmt.Errorf("StringTypers size=%d does not match existing StringTypers size=%d", len(other), len(tim))
+	}
+
+	for i := 0; i < len(tim); i++ {
+		if err := tim[i].Merge(other[i]); err != nil {
+			return fmt.Errorf("column %d: %w", i, err)
+		}
+	}
+	return nil
+}
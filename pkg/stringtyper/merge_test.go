@@ -0,0 +1,111 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type MergeColumnTest struct {
+	column []Column
+	opts   []Option
+}
+
+var testCasesMerge = []MergeColumnTest{
+	MergeColumnTest{column: []Column{"1", "", "300", "-5", "NA", "7"}},
+	MergeColumnTest{column: []Column{"0", "1", "1", "0"}},
+	MergeColumnTest{column: []Column{"1.5", "0", "-2.25", "1e-3", "NaN", "7"}},
+	MergeColumnTest{column: []Column{"abc", "1.5", "x", "2"}},
+	MergeColumnTest{column: []Column{"2021-03-04", "", "1999-12-31", "2020-01-02"}},
+	MergeColumnTest{column: []Column{"03/04/2020", "01/02/2020", "31/12/2020"}},
+	MergeColumnTest{column: []Column{"150ms", "2h", "-3us", "1h"}},
+	MergeColumnTest{column: []Column{"1h", "2", "x", "y"}},
+	MergeColumnTest{column: []Column{"1", "1234567890123456789012345678901234567890", "12.50"}},
+	MergeColumnTest{column: []Column{"0xFF", "10", "0b1"}, opts: []Option{WithGoIntLiterals(true)}},
+	MergeColumnTest{column: []Column{"1.5", "0x10", "5"}, opts: []Option{WithGoIntLiterals(true)}},
+	MergeColumnTest{column: []Column{"NaN", "1.5", "-Inf"}, opts: []Option{WithSpecialFloats(false)}},
+	MergeColumnTest{column: []Column{"1", "x", "12.5", "y", "NaN", "7"}, opts: []Option{WithFailedValuesLimit(1)}},
+	MergeColumnTest{column: []Column{"1,234", "7", "1,5", "x,y", "2"}, opts: []Option{WithLocales(LocaleEN, LocaleDE)}},
+	MergeColumnTest{column: []Column{"1,234", "x,y", "2,345"}, opts: []Option{WithLocales(LocaleEN), WithTolerance(1)}},
+	MergeColumnTest{column: []Column{"1+2i", "3", "1e300i"}},
+	MergeColumnTest{column: []Column{" 1", "x", "2 ", "héllo"}, opts: []Option{WithTrimSpace(true), WithTolerance(1), WithFailedValuesLimit(1)}},
+	MergeColumnTest{column: []Column{"1", "n/a", "2", "3", "oops", "5"}, opts: []Option{WithToleranceFraction(0.4)}},
+	MergeColumnTest{column: []Column{"03/04/2020", "n/a", "31/12/2020", "x"}, opts: []Option{WithToleranceFraction(0.5)}},
+	MergeColumnTest{column: []Column{"true", "n/a", "false", "1"}, opts: []Option{WithTolerance(1)}},
+	MergeColumnTest{column: []Column{"1s", "n/a", "2m", "x", "3h"}, opts: []Option{WithTolerance(2)}},
+}
+
+// TestCasesMerge checks that merging the StringTypers of the two halves of a
+// column, split at every row, gives the same Result as a single pass.
+func TestCasesMerge(t *testing.T) {
+	for _, test := range testCasesMerge {
+		single := NewStringTyper(test.opts...)
+		for _, s := range test.column {
+			single.CheckFieldTypeAndLength(string(s))
+		}
+		want := single.Result()
+
+		for k := 0; k <= len(test.column); k++ {
+			first, second := NewStringTyper(test.opts...), NewStringTyper(test.opts...)
+			for _, s := range test.column[:k] {
+				first.CheckFieldTypeAndLength(string(s))
+			}
+			for _, s := range test.column[k:] {
+				second.CheckFieldTypeAndLength(string(s))
+			}
+
+			if err := first.Merge(second); err != nil {
+				t.Fatal(err)
+			}
+			if got := first.Result(); !reflect.DeepEqual(got, want) {
+				t.Errorf("%v split at %d:\n%+v\n%+v", test.column, k, want, got)
+			}
+			if got := first.Explain(); got != single.Explain() {
+				t.Errorf("%v split at %d:\n%s\n%s", test.column, k, single.Explain(), got)
+			}
+		}
+	}
+}
+
+// Values from different bool vocabularies or time layouts in each half
+func TestMergeMixed(t *testing.T) {
+	for _, column := range [][]string{
+		{"yes", "no", "true"},
+		{"2021-03-04", "03/04/2020"},
+	} {
+		first := NewStringTyper(WithBoolVocabularies(BoolStrconv, BoolYesNo))
+		second := NewStringTyper(WithBoolVocabularies(BoolStrconv, BoolYesNo))
+		for _, s := range column[:len(column)-1] {
+			first.CheckFieldTypeAndLength(s)
+		}
+		second.CheckFieldTypeAndLength(column[len(column)-1])
+
+		if err := first.Merge(second); err != nil {
+			t.Fatal(err)
+		}
+		if c := first.Candidate(); c != CandidateString {
+			t.Error(column, c)
+		}
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	if err := NewStringTyper().Merge(NewStringTyper(WithTimeLayouts())); err == nil {
+		t.Error("merged StringTypers with different time layouts")
+	}
+
+	tim, _ := NewStringTypers(2)
+	other, _ := NewStringTypers(3)
+	if err := tim.Merge(other); err == nil {
+		t.Error("merged StringTypers of different sizes")
+	}
+
+	other, _ = NewStringTypers(2)
+	tim.CheckFieldTypeAndLength([]string{"1", "a"})
+	other.CheckFieldTypeAndLength([]string{"2.5", "b"})
+	if err := tim.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if k := tim.Kinds(); !reflect.DeepEqual(k, []reflect.Kind{reflect.Float32, reflect.String}) {
+		t.Error(k)
+	}
+}
@@ -0,0 +1,219 @@
+package stringtyper
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// number is what strconv makes of a value as a float and as an integer,
+// worked out in a single scan for base 10 integers, which are the most
+// common numbers, and with as few strconv calls as possible otherwise. The
+// float32 and narrower integer results are derived from the 64 bit ones.
+type number struct {
+	v    string
+	base int
+
+	// f64 and f32 are as returned by strconv.ParseFloat(v, 64) and (v, 32)
+	f64, f32     float64
+	err64, err32 error
+
+	// In base 10, the sign and the magnitude of the digits up to the first
+	// character that is not one, as strconv.ParseUint and ParseInt scan them
+	sign     byte
+	mag      uint64
+	overflow bool
+	syntax   bool
+
+	// In base 0, as returned by strconv.ParseUint(v, 0, 64) and ParseInt
+	u          uint64
+	i          int64
+	errU, errI error
+}
+
+// scanNumber parses v, with integers in the given base (0 or 10). The number
+// is returned by value so that it stays on the stack.
+func scanNumber(v string, base int) number {
+	n := number{v: v, base: base}
+	if base == 10 {
+		n.scanInt()
+		if !n.syntax && !n.overflow {
+			n.setIntFloats()
+			return n
+		}
+	} else {
+		n.u, n.errU = strconv.ParseUint(v, base, 64)
+		n.i, n.errI = strconv.ParseInt(v, base, 64)
+	}
+	n.f64, n.err64 = strconv.ParseFloat(v, 64)
+	n.f32, n.err32 = parseFloat32(v, n.f64, n.err64)
+	return n
+}
+
+// scanInt scans v as an optionally signed base 10 integer.
+func (n *number) scanInt() {
+	d := n.v
+	if len(d) > 0 && (d[0] == '+' || d[0] == '-') {
+		n.sign = d[0]
+		d = d[1:]
+	}
+
+	n.syntax = len(d) == 0
+	for i := 0; i < len(d); i++ {
+		c := d[i] - '0'
+		if c > 9 {
+			n.syntax = true
+			return
+		}
+		if n.mag > (math.MaxUint64-uint64(c))/10 {
+			n.overflow = true
+		}
+		n.mag = n.mag*10 + uint64(c)
+	}
+}
+
+// setIntFloats sets the floats of a base 10 integer. Conversions round to
+// nearest even, as strconv does.
+func (n *number) setIntFloats() {
+	n.f64 = float64(n.mag)
+	n.f32 = float64(float32(n.mag))
+	if n.sign == '-' {
+		n.f64 = -n.f64
+		n.f32 = -n.f32
+	}
+}
+
+// uint returns what strconv.ParseUint(v, base, bitSize) would, less the value on error.
+func (n *number) uint(bitSize int) (uint64, error) {
+	max := uint64(1)<<uint(bitSize) - 1
+	if n.base != 10 {
+		if errors.Is(n.errU, strconv.ErrSyntax) && bitSize < 64 {
+			return strconv.ParseUint(n.v, n.base, bitSize)
+		}
+		if n.errU == nil && n.u > max {
+			return 0, rangeError("ParseUint", n.v)
+		}
+		return n.u, n.errU
+	}
+
+	switch {
+	case n.sign != 0:
+		return 0, syntaxError("ParseUint", n.v)
+	case n.overflow || n.mag > max:
+		return 0, rangeError("ParseUint", n.v)
+	case n.syntax:
+		return 0, syntaxError("ParseUint", n.v)
+	}
+	return n.mag, nil
+}
+
+// int returns what strconv.ParseInt(v, base, bitSize) would, less the value on error.
+func (n *number) int(bitSize int) (int64, error) {
+	cutoff := int64(1) << uint(bitSize-1)
+	if n.base != 10 {
+		if errors.Is(n.errI, strconv.ErrSyntax) && bitSize < 64 {
+			return strconv.ParseInt(n.v, n.base, bitSize)
+		}
+		if n.errI == nil && (n.i < -cutoff || n.i > cutoff-1) {
+			return 0, rangeError("ParseInt", n.v)
+		}
+		return n.i, n.errI
+	}
+
+	// Like strconv, the digits are scanned as unsigned before the sign is applied
+	switch {
+	case n.overflow || n.mag > uint64(1)<<uint(bitSize)-1:
+		return 0, rangeError("ParseInt", n.v)
+	case n.syntax:
+		return 0, syntaxError("ParseInt", n.v)
+	case n.sign == '-' && n.mag <= uint64(cutoff):
+		return -int64(n.mag), nil
+	case n.sign != '-' && n.mag < uint64(cutoff):
+		return int64(n.mag), nil
+	}
+	return 0, rangeError("ParseInt", n.v)
+}
+
+// bigInt reports whether v is an integer of any size, as big.Int parses it.
+func (n *number) bigInt() bool {
+	if n.base == 10 {
+		return !n.syntax
+	}
+	_, ok := new(big.Int).SetString(n.v, n.base)
+	return ok
+}
+
+// exactInt reports, for a base 10 integer that fits a uint64, whether f64
+// is exactly it or formats back to it, as exactFloat would. ok is false for
+// other values.
+func (n *number) exactInt() (exact, ok bool) {
+	if n.base != 10 || n.syntax || n.overflow {
+		return false, false
+	}
+	f := math.Abs(n.f64)
+	if f < 0x1p64 && uint64(f) == n.mag {
+		return true, true
+	}
+
+	// The shortest formatting of f, d.ddde+XX, has at most 17 digits
+	var buf [32]byte
+	s := strconv.AppendFloat(buf[:0], f, 'e', -1, 64)
+	var m uint64
+	digits, i := 0, 0
+	for ; s[i] != 'e'; i++ {
+		if s[i] != '.' {
+			m = m*10 + uint64(s[i]-'0')
+			digits++
+		}
+	}
+	exp := 0
+	for _, c := range s[i+2:] {
+		exp = exp*10 + int(c-'0')
+	}
+	for ; digits <= exp; digits++ {
+		if m > math.MaxUint64/10 {
+			return false, true
+		}
+		m *= 10
+	}
+	return m == n.mag, true
+}
+
+// parseFloat32 returns what strconv.ParseFloat(v, 32) would, given what
+// strconv.ParseFloat(v, 64) returned. Rounding f to float32 gives the same
+// result as rounding v, unless f is exactly halfway between two float32s.
+func parseFloat32(v string, f float64, err error) (float64, error) {
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return f, err
+	}
+	if float32Tie(f) {
+		return strconv.ParseFloat(v, 32)
+	}
+	f32 := float64(float32(f))
+	if math.IsInf(f32, 0) {
+		return f32, rangeError("ParseFloat", v)
+	}
+	return f32, nil
+}
+
+// float32Tie reports whether f is exactly halfway between two float32s,
+// including math.MaxFloat32 and the overflow threshold above it.
+func float32Tie(f float64) bool {
+	_, exp := math.Frexp(f)
+	// Below the smallest normal float32 the spacing stays at 2^-149
+	if exp < -125 {
+		exp = -125
+	}
+	// f in units of the spacing of float32s around it
+	r := math.Ldexp(f, 24-exp)
+	return math.Abs(r-math.Trunc(r)) == 0.5
+}
+
+func syntaxError(fn, v string) error {
+	return &strconv.NumError{Func: fn, Num: v, Err: strconv.ErrSyntax}
+}
+
+func rangeError(fn, v string) error {
+	return &strconv.NumError{Func: fn, Num: v, Err: strconv.ErrRange}
+}
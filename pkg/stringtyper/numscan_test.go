@@ -0,0 +1,170 @@
+package stringtyper
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func benchmarkValues(n int, format func(r *rand.Rand) string) []string {
+	r := rand.New(rand.NewSource(1))
+	values := make([]string, n)
+	for i := range values {
+		values[i] = format(r)
+	}
+	return values
+}
+
+var benchmarkColumns = []struct {
+	name   string
+	values []string
+}{
+	{"uint16", benchmarkValues(1<<16, func(r *rand.Rand) string { return strconv.Itoa(r.Intn(60000)) })},
+	{"int64", benchmarkValues(1<<16, func(r *rand.Rand) string { return strconv.FormatInt(r.Int63()-r.Int63(), 10) })},
+	{"float64", benchmarkValues(1<<16, func(r *rand.Rand) string { return strconv.FormatFloat(r.NormFloat64()*1e6, 'f', -1, 64) })},
+	{"string", benchmarkValues(1<<16, func(r *rand.Rand) string { return "id-" + strconv.Itoa(r.Int()) })},
+}
+
+func BenchmarkCheckFieldTypeAndLength(b *testing.B) {
+	for _, column := range benchmarkColumns {
+		b.Run(column.name, func(b *testing.B) {
+			ti := NewStringTyper()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ti.CheckFieldTypeAndLength(column.values[i%len(column.values)])
+			}
+		})
+	}
+}
+
+// strconvNumber makes the strconv calls CheckFieldTypeAndLength made for
+// every value before scanNumber, to compare against it.
+func strconvNumber(v string, base int) (ok int) {
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		ok++
+	}
+	if _, err := strconv.ParseFloat(v, 32); err == nil {
+		ok++
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		ok++
+	}
+	for _, bitSize := range []int{8, 16, 32, 64} {
+		if _, err := strconv.ParseUint(v, base, bitSize); err == nil {
+			ok++
+		}
+		if _, err := strconv.ParseInt(v, base, bitSize); err == nil {
+			ok++
+		}
+	}
+	if _, isInt := new(big.Int).SetString(v, base); isInt {
+		ok++
+	}
+	return ok
+}
+
+var numberSink number
+
+func BenchmarkScanNumber(b *testing.B) {
+	for _, column := range benchmarkColumns {
+		b.Run(column.name+"/scan", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				n := scanNumber(column.values[i%len(column.values)], 10)
+				n.bigInt()
+				numberSink = n
+			}
+		})
+		b.Run(column.name+"/strconv", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				strconvNumber(column.values[i%len(column.values)], 10)
+			}
+		})
+	}
+}
+
+func numberValues() []string {
+	values := []string{
+		"", "+", "-", "0", "-0", "+0", "00012", "1_000", "0x1F", "0o17", "017", "0b101", "0x_1F",
+		"127", "128", "-128", "-129", "255", "256", "32767", "32768", "-32769", "65535", "65536",
+		"2147483647", "2147483648", "-2147483649", "4294967295", "4294967296",
+		"9223372036854775807", "9223372036854775808", "-9223372036854775808", "-9223372036854775809",
+		"18446744073709551615", "18446744073709551616", "+18446744073709551616", "-18446744073709551616",
+		"1234567890123456789012345678901234567890", "16777217", "9007199254740993",
+		"1.5", "-2.25", "1e-50", "1e-46", "1e39", "-1e39", "1e400", "1e-400", "3.4028235e38",
+		"3.40282356e38", "3.4028235677973366e38", "0x1p-2", "NaN", "-Inf", "+Infinity", "abc", "1e", ".",
+		"1+2i", "(1.5)", "1.5.5", "--1", "1-", "186839.12", "300.5", "-300.5", "+300x", "99999999999999999999x",
+		"0x1FF.5", "0xFFFFFFFFFFFFFFFFFF", "1000000000000000000", "12345678901234567800", "9007199254740993", "-0x80", "-0x81", "1_0_0", "_1",
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		u := r.Uint64() >> uint(r.Intn(64))
+		values = append(values, strconv.FormatUint(u, 10), "-"+strconv.FormatUint(u, 10))
+
+		// Halfway between two float32s, exactly and as the shortest float64
+		a := math.Float32frombits(r.Uint32() &^ (1 << 31))
+		if math.IsNaN(float64(a)) || math.IsInf(float64(a), 0) {
+			continue
+		}
+		b := math.Nextafter32(a, float32(math.Inf(1)))
+		tie := float64(a)/2 + float64(b)/2
+		values = append(values,
+			strconv.FormatFloat(tie, 'g', -1, 64),
+			strconv.FormatFloat(tie, 'e', 60, 64),
+			strconv.FormatFloat(math.Nextafter(tie, 0), 'g', -1, 64),
+			strconv.FormatFloat(float64(a), 'g', -1, 32),
+		)
+	}
+	return values
+}
+
+func TestScanNumber(t *testing.T) {
+	errString := func(err error) string {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+
+	for _, base := range []int{10, 0} {
+		for _, v := range numberValues() {
+			n := scanNumber(v, base)
+
+			f64, err64 := strconv.ParseFloat(v, 64)
+			if err64 == nil && n.f64 != f64 && !(math.IsNaN(f64) && math.IsNaN(n.f64)) || errString(n.err64) != errString(err64) {
+				t.Error(v, "float64", f64, err64, n.f64, n.err64)
+			}
+			f32, err32 := strconv.ParseFloat(v, 32)
+			if err32 == nil && n.f32 != f32 && !(math.IsNaN(f32) && math.IsNaN(n.f32)) || errString(n.err32) != errString(err32) {
+				t.Error(v, "float32", f32, err32, n.f32, n.err32)
+			}
+
+			if _, want := new(big.Int).SetString(v, base); n.bigInt() != want {
+				t.Error(v, base, "bigInt", want)
+			}
+			if exact, ok := n.exactInt(); ok {
+				digits, point, _ := parseDecimal(v, false)
+				if want := exactFloat(digits, point, f64, 64); exact != want {
+					t.Error(v, "exact", want, exact)
+				}
+			}
+
+			for _, bits := range []int{8, 16, 32, 64} {
+				want, wantErr := strconv.ParseUint(v, base, bits)
+				got, err := n.uint(bits)
+				if wantErr == nil && got != want || errString(err) != errString(wantErr) {
+					t.Error(v, base, "uint", bits, want, wantErr, got, err)
+				}
+				wantInt, wantErr := strconv.ParseInt(v, base, bits)
+				gotInt, err := n.int(bits)
+				if wantErr == nil && gotInt != wantInt || errString(err) != errString(wantErr) {
+					t.Error(v, base, "int", bits, wantInt, wantErr, gotInt, err)
+				}
+			}
+		}
+	}
+}
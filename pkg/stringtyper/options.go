@@ -0,0 +1,124 @@
+package stringtyper
+
+// Option configures a StringTyper. Options are applied in order by
+// NewStringTyper and NewStringTypers, after the defaults.
+type Option func(*StringTyper)
+
+// WithNullTokens sets the values treated as missing; see SetNullTokens.
+func WithNullTokens(tokens ...string) Option {
+	return func(ti *StringTyper) {
+		ti.SetNullTokens(tokens...)
+	}
+}
+
+// WithCandidates sets the candidate types considered; see SetCandidates.
+func WithCandidates(candidates ...Candidate) Option {
+	return func(ti *StringTyper) {
+		ti.SetCandidates(candidates...)
+	}
+}
+
+// WithBoolVocabularies sets the vocabularies bools may come from; see SetBoolVocabularies.
+func WithBoolVocabularies(vocabularies ...BoolVocabulary) Option {
+	return func(ti *StringTyper) {
+		ti.SetBoolVocabularies(vocabularies...)
+	}
+}
+
+// WithBoolPolicy sets how a column that is both bool and integer is typed; see SetBoolPolicy.
+func WithBoolPolicy(p BoolPolicy) Option {
+	return func(ti *StringTyper) {
+		ti.SetBoolPolicy(p)
+	}
+}
+
+// WithTimeLayouts sets the layouts used to detect times; see SetTimeLayouts.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(ti *StringTyper) {
+		ti.SetTimeLayouts(layouts...)
+	}
+}
+
+// WithSignPolicy sets how the signedness of integers is chosen; see SetSignPolicy.
+func WithSignPolicy(p SignPolicy) Option {
+	return func(ti *StringTyper) {
+		ti.SetSignPolicy(p)
+	}
+}
+
+// WithMinIntWidth sets the narrowest integer type that may be inferred; see SetMinIntWidth.
+func WithMinIntWidth(bits int) Option {
+	return func(ti *StringTyper) {
+		ti.SetMinIntWidth(bits)
+	}
+}
+
+// WithGoIntLiterals accepts integers written as Go integer literals; see SetGoIntLiterals.
+func WithGoIntLiterals(accept bool) Option {
+	return func(ti *StringTyper) {
+		ti.SetGoIntLiterals(accept)
+	}
+}
+
+// WithLocales sets the locales numbers may be written in; see SetLocales.
+func WithLocales(locales ...Locale) Option {
+	return func(ti *StringTyper) {
+		ti.SetLocales(locales...)
+	}
+}
+
+// WithTrimSpace removes white space around values before checking them; see SetTrimSpace.
+func WithTrimSpace(trim bool) Option {
+	return func(ti *StringTyper) {
+		ti.SetTrimSpace(trim)
+	}
+}
+
+// WithCaseFold matches bool vocabularies regardless of case; see SetCaseFold.
+func WithCaseFold(fold bool) Option {
+	return func(ti *StringTyper) {
+		ti.SetCaseFold(fold)
+	}
+}
+
+// WithSpecialFloats allows or rejects NaN and infinite floats; see SetSpecialFloats.
+func WithSpecialFloats(allow bool) Option {
+	return func(ti *StringTyper) {
+		ti.SetSpecialFloats(allow)
+	}
+}
+
+// WithExactDecimals rules out floats that cannot represent every value exactly; see SetExactDecimals.
+func WithExactDecimals(exact bool) Option {
+	return func(ti *StringTyper) {
+		ti.SetExactDecimals(exact)
+	}
+}
+
+// WithHugeInts sets how integers too big for int64 and uint64 are typed; see SetHugeInts.
+func WithHugeInts(p HugeIntPolicy) Option {
+	return func(ti *StringTyper) {
+		ti.SetHugeInts(p)
+	}
+}
+
+// WithTolerance sets how many failing values a numeric candidate survives; see SetTolerance.
+func WithTolerance(n int) Option {
+	return func(ti *StringTyper) {
+		ti.SetTolerance(n)
+	}
+}
+
+// WithToleranceFraction sets the fraction of failing values a numeric candidate survives; see SetToleranceFraction.
+func WithToleranceFraction(f float64) Option {
+	return func(ti *StringTyper) {
+		ti.SetToleranceFraction(f)
+	}
+}
+
+// WithFailedValuesLimit sets how many failing values are kept per candidate; see SetFailedValuesLimit.
+func WithFailedValuesLimit(n int) Option {
+	return func(ti *StringTyper) {
+		ti.SetFailedValuesLimit(n)
+	}
+}
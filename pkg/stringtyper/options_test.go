@@ -0,0 +1,141 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type OptionsColumnTest struct {
+	column []Column
+	opts   []Option
+	kind   reflect.Kind
+}
+
+var testCasesOptions = []OptionsColumnTest{
+	OptionsColumnTest{
+		column: []Column{"1", "?", "2"},
+		opts:   []Option{WithNullTokens("?")},
+		kind:   reflect.Uint8,
+	},
+	OptionsColumnTest{
+		column: []Column{"1", "0"},
+		opts:   []Option{WithCandidates(CandidateInt8, CandidateInt16)},
+		kind:   reflect.Int8,
+	},
+	OptionsColumnTest{
+		column: []Column{"2021-03-04"},
+		opts:   []Option{WithCandidates(CandidateBool)},
+		kind:   reflect.String,
+	},
+	// Setting layouts after restricting candidates does not enable times
+	OptionsColumnTest{
+		column: []Column{"2021-03-04"},
+		opts:   []Option{WithCandidates(CandidateBool), WithTimeLayouts("2006-01-02")},
+		kind:   reflect.String,
+	},
+	// A later call enables again what an earlier one left out
+	OptionsColumnTest{
+		column: []Column{"true"},
+		opts:   []Option{WithCandidates(CandidateInt8), WithCandidates(CandidateInt8, CandidateBool)},
+		kind:   reflect.Bool,
+	},
+	OptionsColumnTest{
+		column: []Column{"2021-03-04"},
+		opts:   []Option{WithCandidates(CandidateBool), WithCandidates(CandidateTime)},
+		kind:   reflect.Struct,
+	},
+	OptionsColumnTest{
+		column: []Column{"1234567890123456789012345"},
+		opts:   []Option{WithCandidates(CandidateBool), WithCandidates(CandidateBigInt)},
+		kind:   reflect.Struct,
+	},
+	OptionsColumnTest{
+		column: []Column{"Y", "n"},
+		opts:   []Option{WithBoolVocabularies(BoolYesNo)},
+		kind:   reflect.Bool,
+	},
+	OptionsColumnTest{
+		column: []Column{"1", "0"},
+		opts:   []Option{WithBoolPolicy(PreferInteger), WithSignPolicy(PreferSigned), WithMinIntWidth(32)},
+		kind:   reflect.Int32,
+	},
+	OptionsColumnTest{
+		column: []Column{"0x10"},
+		opts:   []Option{WithGoIntLiterals(true)},
+		kind:   reflect.Uint8,
+	},
+	OptionsColumnTest{
+		column: []Column{"1.234,5"},
+		opts:   []Option{WithLocales(LocaleDE)},
+		kind:   reflect.Float32,
+	},
+	OptionsColumnTest{
+		column: []Column{"2021-03-04"},
+		opts:   []Option{WithTimeLayouts()},
+		kind:   reflect.String,
+	},
+}
+
+func TestCasesOptions(t *testing.T) {
+	for _, test := range testCasesOptions {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+	}
+}
+
+func TestSetCandidatesAfterValues(t *testing.T) {
+	// Candidates ruled out by a value stay ruled out
+	ti := NewStringTyper()
+	ti.CheckFieldTypeAndLength("x")
+	ti.SetCandidates(CandidateInt8)
+	if k := ti.Kind(); k != reflect.String {
+		t.Error("x", k)
+	}
+
+	// Candidates left out before a value are not checked against it, so
+	// they cannot come back
+	ti = NewStringTyper(WithCandidates(CandidateBool))
+	ti.CheckFieldTypeAndLength("x")
+	ti.SetCandidates(CandidateBool, CandidateUint8)
+	ti.CheckFieldTypeAndLength("5")
+	if k := ti.Kind(); k != reflect.String {
+		t.Error("x 5", k)
+	}
+
+	// Leaving a candidate out still works after values
+	ti = NewStringTyper()
+	ti.CheckFieldTypeAndLength("1")
+	ti.SetCandidates(CandidateInt8)
+	if k := ti.Kind(); k != reflect.Int8 {
+		t.Error("1", k)
+	}
+}
+
+func TestStringTypersOptions(t *testing.T) {
+	tim, err := NewStringTypers(3, WithNullTokens("?"), WithSignPolicy(PreferSigned))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := [][]string{
+		{"-1", "a", "?"},
+		{"?", "b", "300"},
+	}
+	for _, row := range rows {
+		if err := tim.CheckFieldTypeAndLength(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []reflect.Kind{reflect.Int8, reflect.String, reflect.Int16}
+	if kinds := tim.Kinds(); !reflect.DeepEqual(kinds, want) {
+		t.Fatal(want, kinds)
+	}
+}
@@ -0,0 +1,156 @@
+package stringtyper
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// RowReader reads rows of values, one per column, returning io.EOF after the
+// last row. *csv.Reader is a RowReader.
+type RowReader interface {
+	Read() ([]string, error)
+}
+
+// parallelBatchSize is the number of rows a worker checks at a time.
+var parallelBatchSize = 4096
+
+type rowBatch struct {
+	seq   int
+	first int
+	rows  [][]string
+}
+
+type batchResult struct {
+	seq    int
+	typers StringTypers
+	err    error
+}
+
+// CheckRowsParallel reads every row from r and checks it on workers
+// goroutines, returning n StringTypers configured by opts. With workers < 1,
+// runtime.GOMAXPROCS(0) workers are used.
+//
+// The rows are checked in batches, each by its own StringTypers, which are
+// merged in the order the rows were read, so the result is the same as
+// checking the rows one after the other.
+func CheckRowsParallel(r RowReader, n, workers int, opts ...Option) (StringTypers, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	total, err := NewStringTypers(n, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make(chan rowBatch, workers)
+	results := make(chan batchResult, workers)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range batches {
+				results <- b.check(n, opts)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- readBatches(r, batches, stop)
+		close(batches)
+	}()
+
+	// Merge the batches in order, draining the rest after an error
+	var firstErr error
+	pending := make(map[int]batchResult)
+	next := 0
+	for res := range results {
+		if firstErr != nil {
+			continue
+		}
+		pending[res.seq] = res
+		for {
+			p, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			if p.err == nil {
+				p.err = total.Merge(p.typers)
+			}
+			if p.err != nil {
+				firstErr = p.err
+				close(stop)
+				break
+			}
+		}
+	}
+
+	if err := <-readErr; err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return total, nil
+}
+
+// readBatches sends the rows read from r in batches until r is exhausted or
+// stop is closed.
+func readBatches(r RowReader, batches chan<- rowBatch, stop <-chan struct{}) error {
+	b := rowBatch{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// The reader may reuse row, e.g. csv.Reader with ReuseRecord
+		b.rows = append(b.rows, append([]string(nil), row...))
+
+		if len(b.rows) == parallelBatchSize {
+			select {
+			case batches <- b:
+			case <-stop:
+				return nil
+			}
+			b = rowBatch{seq: b.seq + 1, first: b.first + len(b.rows)}
+		}
+	}
+
+	if len(b.rows) > 0 {
+		select {
+		case batches <- b:
+		case <-stop:
+		}
+	}
+	return nil
+}
+
+func (b rowBatch) check(n int, opts []Option) batchResult {
+	res := batchResult{seq: b.seq}
+	res.typers, res.err = NewStringTypers(n, opts...)
+	if res.err != nil {
+		return res
+	}
+	for i, row := range b.rows {
+		if err := res.typers.CheckFieldTypeAndLength(row); err != nil {
+			res.err = fmt.Errorf("row %d: %w", b.first+i+1, err)
+			return res
+		}
+	}
+	return res
+}
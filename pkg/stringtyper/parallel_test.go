@@ -0,0 +1,101 @@
+package stringtyper
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testCSV(rows int) string {
+	var b strings.Builder
+	for i := 0; i < rows; i++ {
+		f := fmt.Sprintf("%d.%d", i%1000, i%7)
+		if i == rows/3 {
+			f = "1e-50"
+		}
+		d := fmt.Sprintf("2021-03-%02d", i%28+1)
+		if i%500 == 0 {
+			d = ""
+		}
+		fmt.Fprintf(&b, "%d,%s,%s,%d\n", i*37, f, d, i%2)
+	}
+	b.WriteString("n/a,x,2021-03-04,1\n")
+	return b.String()
+}
+
+func TestCheckRowsParallel(t *testing.T) {
+	defer func(size int) { parallelBatchSize = size }(parallelBatchSize)
+	parallelBatchSize = 100
+
+	input := testCSV(2500)
+	opts := []Option{WithTolerance(1)}
+
+	serial, _ := NewStringTypers(4, opts...)
+	r := csv.NewReader(strings.NewReader(input))
+	for {
+		row, err := r.Read()
+		if err != nil {
+			break
+		}
+		serial.CheckFieldTypeAndLength(row)
+	}
+	want := serial.Results()
+
+	for _, workers := range []int{0, 1, 2, 7} {
+		r := csv.NewReader(strings.NewReader(input))
+		r.ReuseRecord = true
+		tim, err := CheckRowsParallel(r, 4, workers, opts...)
+		if err != nil {
+			t.Fatal(workers, err)
+		}
+		if got := tim.Results(); !reflect.DeepEqual(got, want) {
+			t.Errorf("workers=%d\n%+v\n%+v", workers, want, got)
+		}
+		if k := tim.Kinds(); !reflect.DeepEqual(k, []reflect.Kind{reflect.Uint32, reflect.Float64, reflect.Struct, reflect.Bool}) {
+			t.Error(workers, k)
+		}
+	}
+}
+
+// errReader reads rows then returns err; the last row is short if short is set.
+type errReader struct {
+	rows  int
+	err   error
+	short bool
+}
+
+func (r *errReader) Read() ([]string, error) {
+	if r.rows == 0 {
+		return nil, r.err
+	}
+	r.rows--
+	if r.rows == 0 && r.short {
+		return []string{"1"}, nil
+	}
+	return []string{"1", "2"}, nil
+}
+
+func TestCheckRowsParallelErrors(t *testing.T) {
+	defer func(size int) { parallelBatchSize = size }(parallelBatchSize)
+	parallelBatchSize = 10
+
+	readErr := errors.New("read failed")
+	if _, err := CheckRowsParallel(&errReader{rows: 95, err: readErr}, 2, 3); err != readErr {
+		t.Error(err)
+	}
+	if _, err := CheckRowsParallel(&errReader{rows: 95, err: io.EOF, short: true}, 2, 3); err == nil || !strings.HasPrefix(err.Error(), "row 95:") {
+		t.Error(err)
+	}
+	if _, err := CheckRowsParallel(&errReader{rows: 95, err: readErr}, 0, 3); err == nil {
+		t.Error("no error for no columns")
+	}
+
+	tim, err := CheckRowsParallel(&errReader{rows: 0, err: io.EOF}, 2, 3)
+	if err != nil || len(tim) != 2 || tim[0].Kind() != reflect.String {
+		t.Error(tim, err)
+	}
+}
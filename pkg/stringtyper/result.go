@@ -0,0 +1,193 @@
+package stringtyper
+
+import (
+	"reflect"
+	"time"
+)
+
+// Result describes the type inferred from the values seen by a StringTyper.
+type Result struct {
+	Candidate Candidate
+	Kind      reflect.Kind
+	// Type is the Go type of the column; a pointer to it when the column is Nullable.
+	Type reflect.Type
+	// GoType is Type as a Go type expression, e.g. "*int16" or "time.Time".
+	GoType string
+
+	Nullable  bool
+	NullCount int
+	// SampleCount is the number of values seen, including nulls.
+	SampleCount int
+
+	// TrimmedCount and FoldedCount are the number of values that needed
+	// cleaning up; see StringTyper.TrimmedCount and StringTyper.FoldedCount.
+	TrimmedCount int
+	FoldedCount  int
+
+	// Lengths are in bytes, RuneLengths in runes, of the values that are not null.
+	MaxLength      int
+	MinLength      int
+	MeanLength     float64
+	MaxRuneLength  int
+	MinRuneLength  int
+	MeanRuneLength float64
+
+	MaxInt        *int64
+	MinInt        *int64
+	MaxUint       *uint64
+	MinUint       *uint64
+	MinFloat      *float64
+	MaxFloat      *float64
+	SmallestFloat *float64
+	MinTime       *time.Time
+	MaxTime       *time.Time
+	MinDuration   *time.Duration
+	MaxDuration   *time.Duration
+
+	NaNCount    int
+	PosInfCount int
+	NegInfCount int
+	// Precision and Scale size a NUMERIC column; see StringTyper.Precision.
+	Precision int
+	Scale     int
+	// PrecisionLoss is true if the float type, or float64, cannot represent
+	// every value exactly; see StringTyper.PrecisionLoss.
+	PrecisionLoss bool
+
+	// How the values were written
+	TimeLayout string
+	// BoolVocabulary is the name of the vocabulary the bools came from.
+	BoolVocabulary string
+	// IntBase is the base of the integers seen, 0 if they need base 0; see StringTyper.IntBase.
+	IntBase int
+	// Locale is the name of the locale the numbers were written in; see StringTyper.Locale.
+	Locale string
+
+	// Failures is the number of values that failed the Candidate but were
+	// tolerated, and FailedValues the first of them; see StringTyper.SetTolerance.
+	Failures     int
+	FailedValues []string
+
+	// Viable lists the candidates every value satisfies; see StringTyper.Viable.
+	Viable []Candidate
+	// Ambiguous is true if every value is both a bool and an integer.
+	Ambiguous bool
+	// Rejected holds, for each candidate ruled out, the reason it was first ruled out.
+	Rejected map[Candidate]string
+}
+
+// Result returns the inferred type along with what was observed while
+// inferring it. The Result does not change as more values are checked.
+func (ti *StringTyper) Result() Result {
+	c := ti.Candidate()
+	r := Result{
+		Candidate: c,
+		Kind:      c.Kind(),
+		Type:      c.Type(),
+		GoType:    c.String(),
+
+		Nullable:       ti.Nullable(),
+		NullCount:      ti.nullCount,
+		SampleCount:    ti.count + ti.nullCount,
+		TrimmedCount:   ti.trimmedCount,
+		FoldedCount:    ti.foldedCount,
+		MaxLength:      ti.maxLength,
+		MinLength:      ti.minLength,
+		MeanLength:     ti.MeanLength(),
+		MaxRuneLength:  ti.maxRuneLength,
+		MinRuneLength:  ti.minRuneLength,
+		MeanRuneLength: ti.MeanRuneLength(),
+
+		MaxInt:        copyInt64(ti.MaxInt),
+		MinInt:        copyInt64(ti.MinInt),
+		MaxUint:       copyUint64(ti.MaxUint),
+		MinUint:       copyUint64(ti.MinUint),
+		MinFloat:      copyFloat64(ti.MinFloat),
+		MaxFloat:      copyFloat64(ti.MaxFloat),
+		SmallestFloat: copyFloat64(ti.SmallestFloat),
+		MinTime:       copyTime(ti.MinTime),
+		MaxTime:       copyTime(ti.MaxTime),
+		MinDuration:   copyDuration(ti.MinDuration),
+		MaxDuration:   copyDuration(ti.MaxDuration),
+
+		NaNCount:    ti.nanCount,
+		PosInfCount: ti.posInfCount,
+		NegInfCount: ti.negInfCount,
+		Precision:   ti.Precision(),
+		Scale:       ti.maxScale,
+
+		PrecisionLoss: ti.PrecisionLoss(),
+
+		TimeLayout:     ti.TimeLayout(),
+		BoolVocabulary: ti.BoolVocabulary(),
+		IntBase:        ti.IntBase(),
+		Locale:         ti.Locale(),
+
+		Failures:     ti.Failures(c),
+		FailedValues: ti.FailedValues(c),
+
+		Viable:    ti.Viable(),
+		Ambiguous: ti.Ambiguous(),
+		Rejected:  make(map[Candidate]string),
+	}
+
+	if r.Nullable {
+		r.Type = reflect.PtrTo(r.Type)
+		r.GoType = "*" + r.GoType
+	}
+
+	for c, err := range ti.rejected {
+		if err != nil {
+			r.Rejected[Candidate(c)] = err.Error()
+		}
+	}
+	return r
+}
+
+func (tim StringTypers) Results() []Result {
+	results := make([]Result, len(tim))
+	for i := range results {
+		results[i] = tim[i].Result()
+	}
+	return results
+}
+
+func copyInt64(p *int64) *int64 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyUint64(p *uint64) *uint64 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyFloat64(p *float64) *float64 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyTime(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyDuration(p *time.Duration) *time.Duration {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
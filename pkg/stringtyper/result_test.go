@@ -0,0 +1,140 @@
+package stringtyper
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type ResultColumnTest struct {
+	column   []Column
+	goType   string
+	typ      reflect.Type
+	rejected []Candidate
+}
+
+var testCasesResult = []ResultColumnTest{
+	ResultColumnTest{
+		column:   []Column{"-1", "300"},
+		goType:   "int16",
+		typ:      reflect.TypeOf(int16(0)),
+		rejected: []Candidate{CandidateBool, CandidateUint8, CandidateInt8, CandidateTime},
+	},
+	ResultColumnTest{
+		column: []Column{"-1", "", "300"},
+		goType: "*int16",
+		typ:    reflect.TypeOf(new(int16)),
+	},
+	ResultColumnTest{
+		column: []Column{"2021-03-04"},
+		goType: "time.Time",
+		typ:    reflect.TypeOf(time.Time{}),
+	},
+	ResultColumnTest{
+		column: []Column{"2021-03-04", "NULL"},
+		goType: "*time.Time",
+		typ:    reflect.TypeOf(new(time.Time)),
+	},
+	ResultColumnTest{
+		column: []Column{"1h", "2m"},
+		goType: "time.Duration",
+		typ:    reflect.TypeOf(time.Duration(0)),
+	},
+	ResultColumnTest{
+		column:   []Column{"a", "b"},
+		goType:   "string",
+		typ:      reflect.TypeOf(""),
+		rejected: []Candidate{CandidateBool, CandidateFloat64, CandidateInt64, CandidateDuration, CandidateTime},
+	},
+}
+
+func TestCasesResult(t *testing.T) {
+	for _, test := range testCasesResult {
+		ti := NewStringTyper()
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		r := ti.Result()
+		if r.GoType != test.goType {
+			t.Error(test.column, "goType", test.goType, r.GoType)
+		}
+		if r.Type != test.typ {
+			t.Error(test.column, "type", test.typ, r.Type)
+		}
+		if r.Kind != ti.Kind() {
+			t.Error(test.column, "kind", ti.Kind(), r.Kind)
+		}
+		if r.SampleCount != len(test.column) {
+			t.Error(test.column, "sampleCount", len(test.column), r.SampleCount)
+		}
+		for _, c := range test.rejected {
+			if r.Rejected[c] == "" {
+				t.Error(test.column, "no reason for rejecting", c, r.Rejected)
+			}
+		}
+		if _, ok := r.Rejected[r.Candidate]; ok {
+			t.Error(test.column, "winner was rejected", r.Candidate, r.Rejected)
+		}
+	}
+}
+
+func TestResultRanges(t *testing.T) {
+	ti := NewStringTyper()
+	for _, s := range []string{"-5", "7", "NA", "100"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+
+	r := ti.Result()
+	if r.MinInt == nil || *r.MinInt != -5 || r.MaxInt == nil || *r.MaxInt != 100 {
+		t.Fatal("int range", r.MinInt, r.MaxInt)
+	}
+	if r.MaxLength != 3 || r.NullCount != 1 || !r.Nullable {
+		t.Fatal(r.MaxLength, r.NullCount, r.Nullable)
+	}
+
+	// The result is a snapshot
+	ti.CheckFieldTypeAndLength("1000")
+	if *r.MaxInt != 100 {
+		t.Fatal("result changed after more values were checked", *r.MaxInt)
+	}
+}
+
+func TestCandidateString(t *testing.T) {
+	for c := CandidateBool; c <= CandidateString; c++ {
+		if c.Type() == nil || c.String() != c.Type().String() {
+			t.Error(int(c), c.Type(), c.String())
+		}
+	}
+	if s := Candidate(-1).String(); s != "Candidate(-1)" {
+		t.Error(s)
+	}
+}
+
+func TestCandidateErrors(t *testing.T) {
+	ti := NewStringTyper(WithLocales(LocaleEN))
+	for _, s := range []string{"7", "300", "abc", "1,5"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+
+	var numErr *strconv.NumError
+	first, last := ti.FirstError(CandidateUint8), ti.LastError(CandidateUint8)
+	if !errors.As(first, &numErr) || numErr.Num != "300" || !errors.Is(first, strconv.ErrRange) {
+		t.Error("first", first)
+	}
+	if !errors.As(last, &numErr) || numErr.Num != "1,5" || !errors.Is(last, ErrNoLocale) {
+		t.Error("last", last)
+	}
+	if err := ti.FirstError(CandidateFloat64); !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("float64", err)
+	}
+	if err := ti.LastError(CandidateFloat64); err != ti.errFloat64 {
+		t.Error("float64", err, ti.errFloat64)
+	}
+	if ti.FirstError(CandidateString) != nil || ti.LastError(Candidate(-1)) != nil {
+		t.Error("no errors expected")
+	}
+}
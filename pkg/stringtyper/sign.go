@@ -0,0 +1,75 @@
+package stringtyper
+
+// SignPolicy decides between the signed and unsigned integer types for
+// columns with no negative values.
+type SignPolicy int
+
+const (
+	// PreferUnsigned picks an unsigned type whenever no value is negative,
+	// e.g. uint8 for "1", "2", "3". It is the default.
+	PreferUnsigned SignPolicy = iota
+	// PreferSigned picks a signed type whenever one can hold every value,
+	// e.g. int16 for "1", "200".
+	PreferSigned
+	// SmallestWidth picks the narrowest type that holds every value, signed
+	// if both are as narrow, e.g. int8 for "1", "2" and uint8 for "1", "200".
+	SmallestWidth
+)
+
+// SetSignPolicy sets how the signedness of integer columns is chosen.
+func (ti *StringTyper) SetSignPolicy(p SignPolicy) {
+	ti.signPolicy = p
+}
+
+// SetMinIntWidth sets the narrowest integer type, in bits, that may be
+// inferred. E.g. with 32 a column of small integers is int32 or uint32,
+// never int8 or uint16. The default is 8.
+func (ti *StringTyper) SetMinIntWidth(bits int) {
+	ti.minIntWidth = bits
+}
+
+var unsignedCandidates = []Candidate{CandidateUint8, CandidateUint16, CandidateUint32, CandidateUint64}
+var signedCandidates = []Candidate{CandidateInt8, CandidateInt16, CandidateInt32, CandidateInt64}
+
+// intCandidate returns the integer candidate chosen by the SignPolicy, if
+// there is one.
+func (ti *StringTyper) intCandidate() (Candidate, bool) {
+	u, uok := ti.narrowestInt(unsignedCandidates)
+	s, sok := ti.narrowestInt(signedCandidates)
+	if !uok || !sok {
+		if uok {
+			return u, true
+		}
+		return s, sok
+	}
+
+	switch ti.signPolicy {
+	case PreferSigned:
+		return s, true
+	case SmallestWidth:
+		if u.Type().Bits() < s.Type().Bits() {
+			return u, true
+		}
+		return s, true
+	}
+	return u, true
+}
+
+// anyOK reports whether one of the candidates is possible.
+func (ti *StringTyper) anyOK(candidates []Candidate) bool {
+	for _, c := range candidates {
+		if ti.ok(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ti *StringTyper) narrowestInt(candidates []Candidate) (Candidate, bool) {
+	for _, c := range candidates {
+		if ti.ok(c) && c.Type().Bits() >= ti.minIntWidth {
+			return c, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,51 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+)
+
+type SignColumnTest struct {
+	column      []Column
+	policy      SignPolicy
+	minIntWidth int
+	kind        reflect.Kind
+}
+
+var testCasesSign = []SignColumnTest{
+	SignColumnTest{column: []Column{"1", "2", "3"}, policy: PreferUnsigned, kind: reflect.Uint8},
+	SignColumnTest{column: []Column{"1", "2", "3"}, policy: PreferSigned, kind: reflect.Int8},
+	SignColumnTest{column: []Column{"1", "2", "3"}, policy: SmallestWidth, kind: reflect.Int8},
+
+	SignColumnTest{column: []Column{"1", "200"}, policy: PreferUnsigned, kind: reflect.Uint8},
+	SignColumnTest{column: []Column{"1", "200"}, policy: PreferSigned, kind: reflect.Int16},
+	SignColumnTest{column: []Column{"1", "200"}, policy: SmallestWidth, kind: reflect.Uint8},
+
+	SignColumnTest{column: []Column{"1", "3000000000"}, policy: PreferSigned, kind: reflect.Int64},
+	SignColumnTest{column: []Column{"1", "18446744073709551615"}, policy: PreferSigned, kind: reflect.Uint64},
+	SignColumnTest{column: []Column{"-1", "2"}, policy: PreferUnsigned, kind: reflect.Int8},
+
+	SignColumnTest{column: []Column{"1", "2", "3"}, policy: PreferUnsigned, minIntWidth: 32, kind: reflect.Uint32},
+	SignColumnTest{column: []Column{"1", "2", "3"}, policy: PreferSigned, minIntWidth: 32, kind: reflect.Int32},
+	SignColumnTest{column: []Column{"-1", "40000"}, policy: PreferSigned, minIntWidth: 16, kind: reflect.Int32},
+	SignColumnTest{column: []Column{"1", "3000000000"}, policy: SmallestWidth, minIntWidth: 64, kind: reflect.Int64},
+	// Bools and floats are not affected
+	SignColumnTest{column: []Column{"1", "0"}, policy: PreferSigned, minIntWidth: 64, kind: reflect.Bool},
+	SignColumnTest{column: []Column{"1", "0.5"}, policy: PreferSigned, minIntWidth: 64, kind: reflect.Float32},
+}
+
+func TestCasesSign(t *testing.T) {
+	for _, test := range testCasesSign {
+		ti := NewStringTyper()
+		ti.SetSignPolicy(test.policy)
+		ti.SetMinIntWidth(test.minIntWidth)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.policy, test.minIntWidth, test.kind, k)
+		}
+	}
+}
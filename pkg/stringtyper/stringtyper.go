@@ -7,6 +7,8 @@ import (
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type StringTyper struct {
@@ -17,6 +19,10 @@ type StringTyper struct {
 	MinFloat      *float64
 	MaxFloat      *float64
 	SmallestFloat *float64
+	MinTime       *time.Time
+	MaxTime       *time.Time
+	MinDuration   *time.Duration
+	MaxDuration   *time.Duration
 	alwaysBool    bool
 	alwaysFloat32 bool
 	alwaysFloat64 bool
@@ -29,10 +35,81 @@ type StringTyper struct {
 	alwaysUint32  bool
 	alwaysUint64  bool
 	maxLength     int
+	minLength     int
+	totalLength   int
 	errFloat64    error
+	nullTokens    map[string]struct{}
+	nullCount     int
+	count         int
+
+	intLiteralBase  int
+	intBase         int
+	mixedIntBase    bool
+	signPolicy      SignPolicy
+	hugeInts        HugeIntPolicy
+	alwaysBigInt    bool
+	hugeIntLoss     bool
+	minIntWidth     int
+	maxRuneLength   int
+	minRuneLength   int
+	totalRuneLength int
+
+	alwaysTime       bool
+	timeLayouts      []string
+	alwaysTimeLayout []bool
+	minTimes         []*time.Time
+	maxTimes         []*time.Time
+	alwaysDuration   bool
+
+	boolPolicy           BoolPolicy
+	boolVocabularies     []BoolVocabulary
+	alwaysBoolVocabulary []bool
+
+	trimSpace    bool
+	trimmedCount int
+	caseFold     bool
+	foldedCount  int
+
+	specialFloats bool
+	nanCount      int
+	posInfCount   int
+	negInfCount   int
+	maxIntDigits  int
+	maxScale      int
+	alwaysDecimal bool
+	exactDecimals bool
+	// precisionLoss is for float64, precisionLoss32 for float32
+	precisionLoss   bool
+	precisionLoss32 bool
+
+	alwaysComplex64  bool
+	alwaysComplex128 bool
+
+	locales      []Locale
+	alwaysLocale []bool
+	// localeTypers check the numbers as written in each locale
+	localeTypers []*StringTyper
+
+	disabled [numCandidates]bool
+	rejected [numCandidates]error
+	// lastRejected is the error from the last value that failed each candidate
+	lastRejected [numCandidates]error
+
+	value             string
+	tolerance         int
+	toleranceFraction float64
+	failures          [numCandidates]int
+	failedAt          [numCandidates]int
+	rejections        [numCandidates][]Rejection
+	failedValuesLimit int
 }
 
-func NewStringTyper() *StringTyper {
+// DefaultNullTokens are the values a new StringTyper treats as missing.
+// Missing values are counted but do not take part in type decisions.
+var DefaultNullTokens = []string{"", "NA", "NULL", "\\N", "-"}
+
+// NewStringTyper returns a StringTyper with the defaults, changed by opts.
+func NewStringTyper(opts ...Option) *StringTyper {
 	ti := StringTyper{
 		alwaysBool:    true,
 		alwaysFloat32: true,
@@ -45,96 +122,246 @@ func NewStringTyper() *StringTyper {
 		alwaysUint16:  true,
 		alwaysUint32:  true,
 		alwaysUint64:  true,
+
+		alwaysDuration: true,
+		alwaysDecimal:  true,
+
+		alwaysComplex64:  true,
+		alwaysComplex128: true,
+	}
+	ti.SetNullTokens(DefaultNullTokens...)
+	ti.SetGoIntLiterals(false)
+	ti.SetBoolVocabularies(DefaultBoolVocabularies...)
+	ti.SetTimeLayouts(DefaultTimeLayouts...)
+	ti.SetMinIntWidth(8)
+	ti.SetHugeInts(HugeIntBigInt)
+	ti.SetSpecialFloats(true)
+	ti.SetFailedValuesLimit(DefaultFailedValuesLimit)
+
+	for _, opt := range opts {
+		opt(&ti)
 	}
 	return &ti
 }
 
-func (ti *StringTyper) CheckFieldTypeAndLength(v string) {
-	l := len(v)
-	if ti.maxLength < l {
-		ti.maxLength = l
+// SetCandidates restricts the candidate types considered to those given.
+// A candidate left out by an earlier call is considered again only if no
+// value has been checked yet, as the values seen were not checked against
+// it. CandidateString is always considered.
+func (ti *StringTyper) SetCandidates(candidates ...Candidate) {
+	wasDisabled := ti.disabled
+	for c := CandidateBool; c < CandidateString; c++ {
+		ti.disabled[c] = true
+	}
+	for _, c := range candidates {
+		if c >= 0 && c < CandidateString {
+			ti.disabled[c] = false
+		}
 	}
+	for c := CandidateBool; c < CandidateString; c++ {
+		if ti.disabled[c] {
+			*ti.flag(c) = false
+		} else if wasDisabled[c] && ti.count == 0 {
+			ti.enable(c)
+		}
+	}
+}
 
-	if _, err := strconv.ParseBool(v); err != nil {
-		ti.alwaysBool = false
+// enable makes the candidate possible again. Bool, time and big.Int also
+// depend on their own settings.
+func (ti *StringTyper) enable(c Candidate) {
+	switch c {
+	case CandidateBool:
+		ti.SetBoolVocabularies(ti.boolVocabularies...)
+	case CandidateTime:
+		ti.SetTimeLayouts(ti.timeLayouts...)
+	case CandidateBigInt:
+		ti.SetHugeInts(ti.hugeInts)
+	default:
+		*ti.flag(c) = true
 	}
+}
 
-	// If the string when converted to a float64 is smaller than the smallest non zero float32, then it should be a float64.
-	// NB: math.SmallestNonzeroFloat32 is a float64
-	//
-	v64, err := strconv.ParseFloat(v, 64)
-	if err == nil {
-		v64 = math.Abs(v64)
-		if v64 > 0.0 && v64 < math.SmallestNonzeroFloat32 {
-			ti.alwaysFloat32 = false
-		}
-		ti.checkFloat(v64)
+// SetNullTokens replaces the set of values treated as missing. Calling it with
+// no tokens disables null detection, so every value takes part in type decisions.
+func (ti *StringTyper) SetNullTokens(tokens ...string) {
+	ti.nullTokens = make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		ti.nullTokens[t] = struct{}{}
 	}
+}
+
+func (ti *StringTyper) isNull(v string) bool {
+	_, ok := ti.nullTokens[v]
+	return ok
+}
+
+// NullCount returns the number of missing values seen.
+func (ti *StringTyper) NullCount() int {
+	return ti.nullCount
+}
 
-	if _, err := strconv.ParseFloat(v, 32); err != nil {
-		ti.alwaysFloat32 = false
+// Nullable reports whether at least one missing value was seen.
+func (ti *StringTyper) Nullable() bool {
+	return ti.nullCount > 0
+}
 
+func (ti *StringTyper) CheckFieldTypeAndLength(v string) {
+	if ti.trimSpace {
+		if t := strings.TrimSpace(v); len(t) != len(v) {
+			ti.trimmedCount++
+			v = t
+		}
 	}
 
-	if _, err := strconv.ParseFloat(v, 64); err != nil {
-		ti.alwaysFloat64 = false
-		ti.MinFloat = nil
-		ti.MaxFloat = nil
-		ti.SmallestFloat = nil
-		ti.errFloat64 = err
+	if ti.isNull(v) {
+		ti.nullCount++
+		return
 	}
+	ti.count++
+	ti.value = v
+
+	ti.checkLength(v)
 
-	var i int64
-	var ui uint64
+	ti.checkBool(v)
+	ti.checkTime(v)
+	ti.checkDuration(v)
 
-	if ui, err = strconv.ParseUint(v, 10, 8); err != nil {
-		ti.alwaysUint08 = false
+	// Once no value can be a number, numbers are not parsed at all
+	if !ti.numeric() {
+		return
+	}
+	if len(ti.locales) == 0 {
+		ti.checkNumber(v)
 	} else {
-		ti.checkUint(ui)
+		ti.checkLocales(v)
+	}
+	if !ti.numeric() {
+		ti.clearNumbers()
+	}
+}
+
+// checkNumber checks v, written as strconv expects it, against the numeric candidates.
+func (ti *StringTyper) checkNumber(v string) {
+	// strconv takes underscores as in Go literals, which are only numbers
+	// with SetGoIntLiterals
+	if ti.intLiteralBase == 10 && strings.IndexByte(v, '_') >= 0 {
+		ti.rejectNumber(syntaxError("ParseFloat", v))
+		return
 	}
 
-	if ui, err = strconv.ParseUint(v, 10, 16); err != nil {
-		ti.alwaysUint16 = false
-	} else {
-		ti.checkUint(ui)
+	digits, point, decimal := ti.checkDecimal(v)
+	n := scanNumber(v, ti.intLiteralBase)
+
+	// If the string when converted to a float64 is smaller than the smallest non zero float32, then it should be a float64.
+	// NB: math.SmallestNonzeroFloat32 is a float64
+	//
+	v64 := n.f64
+	if n.err64 == nil {
+		if ti.checkSpecialFloat(v64) {
+			if !ti.specialFloats {
+				err := &strconv.NumError{Func: "ParseFloat", Num: v, Err: ErrSpecialFloat}
+				ti.reject(CandidateFloat32, err)
+				ti.rejectFloat64(err)
+			}
+		} else {
+			if abs := math.Abs(v64); abs > 0.0 && abs < math.SmallestNonzeroFloat32 {
+				ti.reject(CandidateFloat32, rangeError("ParseFloat", v))
+			}
+			if ti.alwaysFloat64 || ti.tolerant() {
+				ti.checkFloat(v64)
+			}
+			if decimal {
+				ti.checkPrecisionLoss(&n, digits, point)
+			}
+		}
 	}
 
-	if ui, err = strconv.ParseUint(v, 10, 32); err != nil {
-		ti.alwaysUint32 = false
-	} else {
-		ti.checkUint(ui)
+	if n.err32 != nil {
+		ti.reject(CandidateFloat32, n.err32)
+
+	} else if decimal {
+		ti.checkPrecisionLoss32(&n, digits, point)
 	}
 
-	if ui, err = strconv.ParseUint(v, 10, 64); err != nil {
-		ti.alwaysUint64 = false
-	} else {
-		ti.checkUint(ui)
+	if n.err64 != nil {
+		ti.rejectFloat64(n.err64)
 	}
 
-	if i, err = strconv.ParseInt(v, 10, 8); err != nil {
-		ti.alwaysInt08 = false
-	} else {
-		ti.checkInt(i)
+	integer := false
+	for _, c := range unsignedCandidates {
+		u, err := n.uint(c.Type().Bits())
+		if err != nil {
+			ti.reject(c, err)
+		} else if c == CandidateUint64 {
+			ti.checkUint(u)
+			ti.checkIntBase(v)
+			integer = true
+		}
 	}
 
-	if i, err = strconv.ParseInt(v, 10, 16); err != nil {
-		ti.alwaysInt16 = false
-	} else {
-		ti.checkInt(i)
+	for _, c := range signedCandidates {
+		i, err := n.int(c.Type().Bits())
+		if err != nil {
+			ti.reject(c, err)
+		} else if c == CandidateInt64 {
+			ti.checkInt(i)
+			ti.checkIntBase(v)
+			integer = true
+		}
 	}
 
-	if i, err = strconv.ParseInt(v, 10, 32); err != nil {
-		ti.alwaysInt32 = false
-	} else {
-		ti.checkInt(i)
+	if !integer {
+		ti.checkBigInt(&n)
 	}
 
-	if i, err = strconv.ParseInt(v, 10, 64); err != nil {
-		ti.alwaysInt64 = false
-	} else {
-		ti.checkInt(i)
+	ti.checkComplex(&n)
+}
+
+// rejectNumber rules out all the numeric candidates.
+func (ti *StringTyper) rejectNumber(err error) {
+	ti.reject(CandidateUint8, err)
+	ti.reject(CandidateUint16, err)
+	ti.reject(CandidateUint32, err)
+	ti.reject(CandidateUint64, err)
+	ti.reject(CandidateInt8, err)
+	ti.reject(CandidateInt16, err)
+	ti.reject(CandidateInt32, err)
+	ti.reject(CandidateInt64, err)
+	ti.reject(CandidateFloat32, err)
+	ti.rejectFloat64(err)
+	ti.reject(CandidateBigInt, err)
+	ti.reject(CandidateDecimal, err)
+	ti.reject(CandidateComplex64, err)
+	ti.reject(CandidateComplex128, err)
+}
+
+func (ti *StringTyper) rejectFloat64(err error) {
+	ti.reject(CandidateFloat64, err)
+	ti.errFloat64 = err
+	// With a tolerance the range is of the values that are floats
+	if !ti.tolerant() {
+		ti.MinFloat = nil
+		ti.MaxFloat = nil
+		ti.SmallestFloat = nil
 	}
+}
 
+// clearNumbers forgets what was seen of the values as numbers, once none of
+// them can be one.
+func (ti *StringTyper) clearNumbers() {
+	ti.MinUint, ti.MaxUint = nil, nil
+	ti.MinInt, ti.MaxInt = nil, nil
+	ti.MinFloat, ti.MaxFloat, ti.SmallestFloat = nil, nil, nil
+	ti.intBase = 0
+	ti.mixedIntBase = false
+	ti.hugeIntLoss = false
+	ti.nanCount, ti.posInfCount, ti.negInfCount = 0, 0, 0
+	ti.maxIntDigits, ti.maxScale = 0, 0
+	ti.precisionLoss, ti.precisionLoss32 = false, false
+	for i := range ti.alwaysLocale {
+		ti.alwaysLocale[i] = false
+	}
 }
 
 func (ti *StringTyper) checkUint(i uint64) {
@@ -156,6 +383,10 @@ func (ti *StringTyper) checkUint(i uint64) {
 	}
 }
 
+func (ti *StringTyper) alwaysUint() bool {
+	return ti.alwaysUint08 || ti.alwaysUint16 || ti.alwaysUint32 || ti.alwaysUint64
+}
+
 func (ti *StringTyper) alwaysInt() bool {
 	return ti.alwaysInt08 || ti.alwaysInt16 || ti.alwaysInt32 || ti.alwaysInt64
 }
@@ -201,70 +432,81 @@ func (ti *StringTyper) checkFloat(v float64) {
 		ti.SmallestFloat = new(float64)
 		*ti.SmallestFloat = v
 	} else {
-		if v > 0 && v < *ti.SmallestFloat {
+		if v > 0 && (*ti.SmallestFloat == 0 || v < *ti.SmallestFloat) {
 			*ti.SmallestFloat = v
 		}
 	}
 
 }
 
+// Kind returns the reflect.Kind of the inferred type. Kind cannot tell a
+// time.Duration from an int64, or a time.Time from any other struct; use
+// Candidate or Result for that.
 func (ti *StringTyper) Kind() reflect.Kind {
-	if ti.alwaysBool {
-		return reflect.Bool
+	return ti.Candidate().Kind()
+}
+
+// Candidate returns the inferred type: the most specific candidate satisfied
+// by every value seen.
+func (ti *StringTyper) Candidate() Candidate {
+	// Nothing but missing values (or nothing at all) says nothing about the type
+	if ti.count == 0 {
+		return CandidateString
 	}
 
-	if ti.alwaysUint08 {
-		return reflect.Uint8
+	if ti.ok(CandidateBool) && !(ti.boolPolicy == PreferInteger && ti.Ambiguous()) {
+		return CandidateBool
 	}
 
-	if ti.alwaysUint16 {
-		return reflect.Uint16
+	if ti.ok(CandidateTime) {
+		return CandidateTime
 	}
 
-	if ti.alwaysUint32 {
-		return reflect.Uint32
+	if c, ok := ti.intCandidate(); ok {
+		return c
 	}
 
-	if ti.alwaysUint64 {
-		return reflect.Uint64
+	if ti.ok(CandidateBigInt) && ti.hugeIntLoss {
+		return CandidateBigInt
 	}
 
-	if ti.alwaysInt08 {
-		return reflect.Int8
+	if ti.ok(CandidateFloat32) {
+		return CandidateFloat32
 	}
 
-	if ti.alwaysInt16 {
-		return reflect.Int16
+	if ti.ok(CandidateFloat64) {
+		return CandidateFloat64
 	}
 
-	if ti.alwaysInt32 {
-		return reflect.Int32
+	if ti.ok(CandidateDecimal) {
+		return CandidateDecimal
 	}
 
-	if ti.alwaysInt64 {
-		return reflect.Int64
+	if ti.ok(CandidateComplex64) {
+		return CandidateComplex64
 	}
 
-	if ti.alwaysFloat32 {
-		return reflect.Float32
+	if ti.ok(CandidateComplex128) {
+		return CandidateComplex128
 	}
 
-	if ti.alwaysFloat64 {
-		return reflect.Float64
+	if ti.ok(CandidateDuration) {
+		return CandidateDuration
 	}
-	return reflect.String
+	return CandidateString
 }
 
 type StringTypers []*StringTyper
 
-func NewStringTypers(n int) (StringTypers, error) {
+// NewStringTypers returns n StringTypers, one per column, all configured by opts.
+func NewStringTypers(n int, opts ...Option) (StringTypers, error) {
 	if n <= 0 {
 		return nil, errors.New("n<=1")
 	}
 	typeInfos := make(StringTypers, n)
 
 	for i := 0; i < n; i++ {
-		typeInfos[i] = NewStringTyper()
+		typeInfos[i] = NewStringTyper(opts...)
 	}
 
 	return typeInfos, nil
@@ -570,6 +570,79 @@ func TestCasesIncorrectType(t *testing.T) {
 	}
 }
 
+type NullColumnTest struct {
+	column     []Column
+	nullTokens []string
+	kind       reflect.Kind
+	nullCount  int
+}
+
+var testCasesNulls = []NullColumnTest{
+	NullColumnTest{
+		column:    []Column{"1", "", "3", "NA", "-", "NULL", "\\N", "42"},
+		kind:      reflect.Uint8,
+		nullCount: 5,
+	},
+	NullColumnTest{
+		column:    []Column{"-1", "", "300"},
+		kind:      reflect.Int16,
+		nullCount: 1,
+	},
+	NullColumnTest{
+		column:    []Column{"true", "", "false"},
+		kind:      reflect.Bool,
+		nullCount: 1,
+	},
+	NullColumnTest{
+		column:    []Column{"", "NA", ""},
+		kind:      reflect.String,
+		nullCount: 3,
+	},
+	NullColumnTest{
+		column:     []Column{"1.5", "?", "2"},
+		kind:       reflect.Float32,
+		nullTokens: []string{"?"},
+		nullCount:  1,
+	},
+	// NA is no longer a null token
+	NullColumnTest{
+		column:     []Column{"1", "NA", "2"},
+		kind:       reflect.String,
+		nullTokens: []string{""},
+		nullCount:  0,
+	},
+	// No null tokens at all
+	NullColumnTest{
+		column:     []Column{"1", "", "2"},
+		kind:       reflect.String,
+		nullTokens: []string{},
+		nullCount:  0,
+	},
+}
+
+func TestCasesNulls(t *testing.T) {
+	for _, test := range testCasesNulls {
+		ti := NewStringTyper()
+		if test.nullTokens != nil {
+			ti.SetNullTokens(test.nullTokens...)
+		}
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if n := ti.NullCount(); n != test.nullCount {
+			t.Error(test.column, "nullCount", test.nullCount, n)
+		}
+		if ti.Nullable() != (test.nullCount > 0) {
+			t.Error(test.column, "nullable", test.nullCount > 0, ti.Nullable())
+		}
+	}
+}
+
 func Int64(i int64) *int64 {
 	return &i
 }
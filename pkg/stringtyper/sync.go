@@ -0,0 +1,100 @@
+package stringtyper
+
+import (
+	"reflect"
+	"sync"
+)
+
+// SyncStringTyper is a StringTyper that may be used from many goroutines at
+// once.
+type SyncStringTyper struct {
+	mu sync.Mutex
+	ti *StringTyper
+}
+
+// NewSyncStringTyper returns a SyncStringTyper with the defaults, changed by opts.
+func NewSyncStringTyper(opts ...Option) *SyncStringTyper {
+	return &SyncStringTyper{ti: NewStringTyper(opts...)}
+}
+
+func (s *SyncStringTyper) CheckFieldTypeAndLength(v string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ti.CheckFieldTypeAndLength(v)
+}
+
+// Merge adds what other has seen; see StringTyper.Merge. other must not be
+// in use by another goroutine.
+func (s *SyncStringTyper) Merge(other *StringTyper) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ti.Merge(other)
+}
+
+func (s *SyncStringTyper) Kind() reflect.Kind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ti.Kind()
+}
+
+func (s *SyncStringTyper) Candidate() Candidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ti.Candidate()
+}
+
+// Result returns a snapshot of what has been seen so far; see StringTyper.Result.
+func (s *SyncStringTyper) Result() Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ti.Result()
+}
+
+func (s *SyncStringTyper) Explain() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ti.Explain()
+}
+
+// SyncStringTypers are StringTypers, one per column, that may be used from
+// many goroutines at once. Each row is checked as a whole.
+type SyncStringTypers struct {
+	mu  sync.Mutex
+	tim StringTypers
+}
+
+// NewSyncStringTypers returns n SyncStringTypers, all configured by opts.
+func NewSyncStringTypers(n int, opts ...Option) (*SyncStringTypers, error) {
+	tim, err := NewStringTypers(n, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncStringTypers{tim: tim}, nil
+}
+
+func (s *SyncStringTypers) CheckFieldTypeAndLength(vs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tim.CheckFieldTypeAndLength(vs)
+}
+
+// Merge adds what other has seen; see StringTypers.Merge. other must not be
+// in use by another goroutine.
+func (s *SyncStringTypers) Merge(other StringTypers) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tim.Merge(other)
+}
+
+func (s *SyncStringTypers) Kinds() []reflect.Kind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tim.Kinds()
+}
+
+// Results returns a snapshot of what has been seen so far.
+func (s *SyncStringTypers) Results() []Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tim.Results()
+}
@@ -0,0 +1,66 @@
+package stringtyper
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Run with go test -race
+func TestSyncStringTyper(t *testing.T) {
+	s := NewSyncStringTyper(WithFailedValuesLimit(100))
+	tim, err := NewSyncStringTypers(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			local := NewStringTyper()
+			for i := 0; i < 500; i++ {
+				v := strconv.Itoa(g*1000 + i)
+				s.CheckFieldTypeAndLength(v)
+				local.CheckFieldTypeAndLength("-" + v)
+				if err := tim.CheckFieldTypeAndLength([]string{v, "x"}); err != nil {
+					t.Error(err)
+				}
+				if i%100 == 0 {
+					s.Result()
+					s.Explain()
+					tim.Results()
+				}
+			}
+			if err := s.Merge(local); err != nil {
+				t.Error(err)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	r := s.Result()
+	if r.SampleCount != 8*1000 || *r.MinInt != -7499 || *r.MaxInt != 7499 {
+		t.Error(r.SampleCount, *r.MinInt, *r.MaxInt)
+	}
+	if s.Kind() != reflect.Int16 || s.Candidate() != CandidateInt16 {
+		t.Error(s.Kind())
+	}
+	if k := tim.Kinds(); !reflect.DeepEqual(k, []reflect.Kind{reflect.Uint16, reflect.String}) {
+		t.Error(k)
+	}
+	if r := tim.Results(); r[0].SampleCount != 8*500 {
+		t.Error(r[0].SampleCount)
+	}
+
+	other, _ := NewStringTypers(2)
+	other.CheckFieldTypeAndLength([]string{"-1", "y"})
+	if err := tim.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if k := tim.Kinds(); k[0] != reflect.Int16 {
+		t.Error(k)
+	}
+}
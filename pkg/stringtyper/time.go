@@ -0,0 +1,175 @@
+package stringtyper
+
+import (
+	"strconv"
+	"time"
+)
+
+// Pseudo layouts for integer Unix epoch timestamps. Only plausible
+// timestamps are accepted: 9 or 10 digits for seconds and 12 or 13 for
+// milliseconds, i.e. from 1973 to 2286. They are not in DefaultTimeLayouts:
+// as time is checked before the integer kinds, adding them still makes any
+// column of such integers, e.g. IDs, a time column.
+const (
+	LayoutUnixSeconds = "unix"
+	LayoutUnixMillis  = "unixmilli"
+)
+
+// DefaultTimeLayouts are the layouts a new StringTyper tries, in order of
+// preference. When a value satisfies more than one layout (e.g. "03/04/2020"
+// is both a US and an EU date) the first layout still satisfied by every
+// value wins.
+var DefaultTimeLayouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"01/02/2006", // US
+	"02/01/2006", // EU
+}
+
+// SetTimeLayouts replaces the layouts used to detect time.Time values.
+// Calling it with no layouts disables time detection.
+func (ti *StringTyper) SetTimeLayouts(layouts ...string) {
+	ti.timeLayouts = append([]string(nil), layouts...)
+	ti.alwaysTimeLayout = make([]bool, len(layouts))
+	for i := range ti.alwaysTimeLayout {
+		ti.alwaysTimeLayout[i] = true
+	}
+	ti.minTimes = make([]*time.Time, len(layouts))
+	ti.maxTimes = make([]*time.Time, len(layouts))
+	ti.alwaysTime = len(layouts) > 0 && !ti.disabled[CandidateTime]
+	ti.MinTime = nil
+	ti.MaxTime = nil
+}
+
+// TimeLayout returns the layout satisfied by every value, or "" if the values
+// are not all times.
+func (ti *StringTyper) TimeLayout() string {
+	if i := ti.timeLayoutIndex(); i >= 0 {
+		return ti.timeLayouts[i]
+	}
+	return ""
+}
+
+func (ti *StringTyper) timeLayoutIndex() int {
+	if !ti.ok(CandidateTime) {
+		return -1
+	}
+	for i, ok := range ti.alwaysTimeLayout {
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ti *StringTyper) checkTime(v string) {
+	if !ti.checking(CandidateTime) {
+		return
+	}
+
+	// With a tolerance, a value that is no time is a failure and rules no
+	// layout out, as in checkBool
+	first := -1
+	var lastErr error
+	for i, layout := range ti.timeLayouts {
+		if !ti.alwaysTimeLayout[i] {
+			continue
+		}
+		t, err := parseTime(layout, v)
+		if err != nil {
+			if first >= 0 || !ti.tolerant() {
+				ti.alwaysTimeLayout[i] = false
+			}
+			lastErr = err
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+		if ti.minTimes[i] == nil || t.Before(*ti.minTimes[i]) {
+			ti.minTimes[i] = &t
+		}
+		if ti.maxTimes[i] == nil || t.After(*ti.maxTimes[i]) {
+			ti.maxTimes[i] = &t
+		}
+	}
+
+	if first < 0 {
+		ti.reject(CandidateTime, lastErr)
+	}
+	for i := 0; i < first; i++ {
+		ti.alwaysTimeLayout[i] = false
+	}
+	ti.setTimeRange()
+}
+
+// setTimeRange sets MinTime and MaxTime to the range of the time layout.
+func (ti *StringTyper) setTimeRange() {
+	if i := ti.timeLayoutIndex(); i >= 0 {
+		ti.MinTime = ti.minTimes[i]
+		ti.MaxTime = ti.maxTimes[i]
+	} else {
+		ti.MinTime = nil
+		ti.MaxTime = nil
+	}
+}
+
+func parseTime(layout, v string) (time.Time, error) {
+	switch layout {
+	case LayoutUnixSeconds:
+		n, err := parseUnix(v, 9, 10)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(n, 0).UTC(), nil
+
+	case LayoutUnixMillis:
+		n, err := parseUnix(v, 12, 13)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(n).UTC(), nil
+	}
+	return time.Parse(layout, v)
+}
+
+// parseUnix parses v, an epoch timestamp of minDigits to maxDigits digits.
+func parseUnix(v string, minDigits, maxDigits int) (int64, error) {
+	if len(v) < minDigits || len(v) > maxDigits || !isDigits(v) {
+		return 0, &strconv.NumError{Func: "ParseUnix", Num: v, Err: strconv.ErrSyntax}
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// IsDuration reports whether every value is a time.Duration, as accepted by
+// time.ParseDuration.
+func (ti *StringTyper) IsDuration() bool {
+	return ti.count > 0 && ti.ok(CandidateDuration)
+}
+
+func (ti *StringTyper) checkDuration(v string) {
+	if !ti.checking(CandidateDuration) {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		ti.reject(CandidateDuration, err)
+		// With a tolerance the range is of the values that are durations
+		if !ti.tolerant() {
+			ti.MinDuration = nil
+			ti.MaxDuration = nil
+		}
+		return
+	}
+
+	if ti.MinDuration == nil || d < *ti.MinDuration {
+		ti.MinDuration = &d
+	}
+	if ti.MaxDuration == nil || d > *ti.MaxDuration {
+		ti.MaxDuration = &d
+	}
+}
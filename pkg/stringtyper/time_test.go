@@ -0,0 +1,212 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type TimeColumnTest struct {
+	column  []Column
+	layouts []string
+	kind    reflect.Kind
+	layout  string
+	minTime *time.Time
+	maxTime *time.Time
+}
+
+var testCasesTime = []TimeColumnTest{
+	TimeColumnTest{
+		column:  []Column{"2021-03-04T05:06:07Z", "2020-01-02T03:04:05.123456789+02:00"},
+		kind:    reflect.Struct,
+		layout:  time.RFC3339,
+		minTime: Time(time.Date(2020, 1, 2, 1, 4, 5, 123456789, time.UTC)),
+		maxTime: Time(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)),
+	},
+	TimeColumnTest{
+		column:  []Column{"2021-03-04", "", "1999-12-31"},
+		kind:    reflect.Struct,
+		layout:  "2006-01-02",
+		minTime: Time(time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)),
+		maxTime: Time(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)),
+	},
+	TimeColumnTest{
+		column: []Column{"2021-03-04 10:11:12"},
+		kind:   reflect.Struct,
+		layout: "2006-01-02 15:04:05",
+	},
+	// Ambiguous: US wins as it is first
+	TimeColumnTest{
+		column:  []Column{"03/04/2020", "01/02/2020"},
+		kind:    reflect.Struct,
+		layout:  "01/02/2006",
+		minTime: Time(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+		maxTime: Time(time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)),
+	},
+	// 31/12 is not a US date
+	TimeColumnTest{
+		column:  []Column{"03/04/2020", "31/12/2020"},
+		kind:    reflect.Struct,
+		layout:  "02/01/2006",
+		minTime: Time(time.Date(2020, 4, 3, 0, 0, 0, 0, time.UTC)),
+		maxTime: Time(time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)),
+	},
+	TimeColumnTest{
+		column:  []Column{"1600000000", "999999999"},
+		layouts: []string{LayoutUnixSeconds},
+		kind:    reflect.Struct,
+		layout:  LayoutUnixSeconds,
+		minTime: Time(time.Unix(999999999, 0).UTC()),
+		maxTime: Time(time.Unix(1600000000, 0).UTC()),
+	},
+	TimeColumnTest{
+		column:  []Column{"1600000000123"},
+		layouts: []string{LayoutUnixMillis},
+		kind:    reflect.Struct,
+		layout:  LayoutUnixMillis,
+		minTime: Time(time.UnixMilli(1600000000123).UTC()),
+	},
+	// Small integers are not epoch timestamps
+	TimeColumnTest{
+		column:  []Column{"1", "2", "300"},
+		layouts: []string{LayoutUnixSeconds, LayoutUnixMillis},
+		kind:    reflect.Uint16,
+	},
+	TimeColumnTest{
+		column:  []Column{"1600000000", "0"},
+		layouts: []string{LayoutUnixSeconds},
+		kind:    reflect.Uint32,
+	},
+	TimeColumnTest{
+		column:  []Column{"1600000000", "-1600000000", "+160000000"},
+		layouts: []string{LayoutUnixSeconds},
+		kind:    reflect.Int32,
+	},
+	TimeColumnTest{
+		column:  []Column{"1600000000"},
+		layouts: []string{LayoutUnixMillis},
+		kind:    reflect.Uint32,
+	},
+	// Epoch layouts are not on by default
+	TimeColumnTest{
+		column: []Column{"1600000000", "0"},
+		kind:   reflect.Uint32,
+	},
+	// Mixed layouts
+	TimeColumnTest{
+		column: []Column{"2021-03-04", "03/04/2020"},
+		kind:   reflect.String,
+	},
+	TimeColumnTest{
+		column: []Column{"2021-03-04", "x"},
+		kind:   reflect.String,
+	},
+	// Time detection disabled
+	TimeColumnTest{
+		column:  []Column{"2021-03-04"},
+		layouts: []string{},
+		kind:    reflect.String,
+	},
+}
+
+func TestCasesTime(t *testing.T) {
+	for _, test := range testCasesTime {
+		ti := NewStringTyper()
+		if test.layouts != nil {
+			ti.SetTimeLayouts(test.layouts...)
+		}
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if l := ti.TimeLayout(); l != test.layout {
+			t.Error(test.column, "layout", test.layout, l)
+		}
+		if test.minTime != nil && (ti.MinTime == nil || !ti.MinTime.Equal(*test.minTime)) {
+			t.Error(test.column, "minTime", test.minTime, ti.MinTime)
+		}
+		if test.maxTime != nil && (ti.MaxTime == nil || !ti.MaxTime.Equal(*test.maxTime)) {
+			t.Error(test.column, "maxTime", test.maxTime, ti.MaxTime)
+		}
+		if test.layout == "" && (ti.MinTime != nil || ti.MaxTime != nil) {
+			t.Error(test.column, "time range on non-time column", ti.MinTime, ti.MaxTime)
+		}
+	}
+}
+
+type DurationColumnTest struct {
+	column      []Column
+	kind        reflect.Kind
+	isDuration  bool
+	minDuration *time.Duration
+	maxDuration *time.Duration
+}
+
+var testCasesDuration = []DurationColumnTest{
+	DurationColumnTest{
+		column:      []Column{"150ms", "2h30m", "1.5s", "-3us"},
+		kind:        reflect.Int64,
+		isDuration:  true,
+		minDuration: Duration(-3 * time.Microsecond),
+		maxDuration: Duration(2*time.Hour + 30*time.Minute),
+	},
+	DurationColumnTest{
+		column:      []Column{"0", "", "1h"},
+		kind:        reflect.Int64,
+		isDuration:  true,
+		minDuration: Duration(0),
+		maxDuration: Duration(time.Hour),
+	},
+	// Still a bool
+	DurationColumnTest{
+		column:     []Column{"0", "0"},
+		kind:       reflect.Bool,
+		isDuration: true,
+	},
+	DurationColumnTest{
+		column: []Column{"150ms", "10"},
+		kind:   reflect.String,
+	},
+	DurationColumnTest{
+		column: []Column{"150ms", "2x"},
+		kind:   reflect.String,
+	},
+}
+
+func TestCasesDuration(t *testing.T) {
+	for _, test := range testCasesDuration {
+		ti := NewStringTyper()
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		if k := ti.Kind(); k != test.kind {
+			t.Error(test.column, test.kind, k)
+		}
+		if ti.IsDuration() != test.isDuration {
+			t.Error(test.column, "isDuration", test.isDuration, ti.IsDuration())
+		}
+		if test.minDuration != nil && (ti.MinDuration == nil || *ti.MinDuration != *test.minDuration) {
+			t.Error(test.column, "minDuration", *test.minDuration, ti.MinDuration)
+		}
+		if test.maxDuration != nil && (ti.MaxDuration == nil || *ti.MaxDuration != *test.maxDuration) {
+			t.Error(test.column, "maxDuration", *test.maxDuration, ti.MaxDuration)
+		}
+		if !test.isDuration && (ti.MinDuration != nil || ti.MaxDuration != nil) {
+			t.Error(test.column, "duration range on non-duration column", ti.MinDuration, ti.MaxDuration)
+		}
+	}
+}
+
+func Duration(d time.Duration) *time.Duration {
+	return &d
+}
+
+func Time(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,120 @@
+package stringtyper
+
+// DefaultFailedValuesLimit is how many failing values a new StringTyper keeps
+// per candidate.
+const DefaultFailedValuesLimit = 10
+
+// SetTolerance lets a candidate survive up to n values that fail it, so one
+// stray "n/a" among a million integers or dates does not make the column a
+// string. A value that fails every bool vocabulary, time layout or locale
+// rules none of them out. The default is 0.
+func (ti *StringTyper) SetTolerance(n int) {
+	ti.tolerance = n
+}
+
+// SetToleranceFraction lets a candidate survive failures by up to
+// the fraction f of the values that are not null, e.g. 0.001 for one in a
+// thousand. The default is 0.
+func (ti *StringTyper) SetToleranceFraction(f float64) {
+	ti.toleranceFraction = f
+}
+
+// SetFailedValuesLimit sets how many failing values are kept per candidate.
+func (ti *StringTyper) SetFailedValuesLimit(n int) {
+	ti.failedValuesLimit = n
+}
+
+// Failures returns the number of values that failed the candidate. Without a
+// tolerance, bool, time and duration are only checked up to their first
+// failure.
+func (ti *StringTyper) Failures(c Candidate) int {
+	if c < 0 || c >= numCandidates {
+		return 0
+	}
+	return ti.failures[c]
+}
+
+// FailedValues returns the first values that failed the candidate, up to the
+// limit set by SetFailedValuesLimit.
+func (ti *StringTyper) FailedValues(c Candidate) []string {
+	var values []string
+	for _, r := range ti.Rejections(c) {
+		values = append(values, r.Value)
+	}
+	return values
+}
+
+// fail counts the value being checked as a failure of the candidate, once
+// however many times the candidate is rejected by it.
+func (ti *StringTyper) fail(c Candidate, err error) {
+	if ti.failedAt[c] == ti.count {
+		return
+	}
+	ti.failedAt[c] = ti.count
+	ti.failures[c]++
+	if len(ti.rejections[c]) < ti.failedValuesLimit {
+		ti.rejections[c] = append(ti.rejections[c], Rejection{Value: ti.value, Row: ti.count + ti.nullCount, Err: err})
+	}
+}
+
+// checking reports whether it is worth checking values against the
+// candidate: it is considered, and still possible or tolerant. Numbers are
+// also parsed for a numeric candidate until as many of its failing values
+// are kept as the limit set by SetFailedValuesLimit.
+func (ti *StringTyper) checking(c Candidate) bool {
+	if !ti.considered(c) {
+		return false
+	}
+	if *ti.flag(c) || ti.tolerant() {
+		return true
+	}
+	return c >= CandidateUint8 && c <= CandidateComplex128 && len(ti.rejections[c]) < ti.failedValuesLimit
+}
+
+// considered reports whether the candidate may be inferred at all: it is not
+// left out by SetCandidates, nor by its own settings, e.g. bool with no
+// vocabularies or big.Int with a HugeIntPolicy that does not consider it.
+func (ti *StringTyper) considered(c Candidate) bool {
+	switch {
+	case ti.disabled[c]:
+		return false
+	case c == CandidateBool:
+		return len(ti.boolVocabularies) > 0
+	case c == CandidateTime:
+		return len(ti.timeLayouts) > 0
+	case c == CandidateBigInt:
+		return ti.hugeInts == HugeIntBigInt
+	}
+	return true
+}
+
+// numeric reports whether values are still parsed as numbers. While they
+// are, the failures of every numeric candidate are counted.
+func (ti *StringTyper) numeric() bool {
+	for c := CandidateUint8; c <= CandidateComplex128; c++ {
+		if ti.checking(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ti *StringTyper) tolerant() bool {
+	return ti.tolerance > 0 || ti.toleranceFraction > 0
+}
+
+// ok reports whether the candidate is still possible: every value satisfied
+// it or the values that did not are within the tolerance.
+func (ti *StringTyper) ok(c Candidate) bool {
+	if *ti.flag(c) {
+		return true
+	}
+	if !ti.considered(c) {
+		return false
+	}
+	n := ti.failures[c]
+	if n == 0 || n >= ti.count {
+		return false
+	}
+	return n <= ti.tolerance || float64(n) <= ti.toleranceFraction*float64(ti.count)
+}
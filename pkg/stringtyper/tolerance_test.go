@@ -0,0 +1,262 @@
+package stringtyper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type ToleranceColumnTest struct {
+	column       []Column
+	opts         []Option
+	candidate    Candidate
+	failures     int
+	failedValues []string
+}
+
+var testCasesTolerance = []ToleranceColumnTest{
+	ToleranceColumnTest{
+		column:    []Column{"1", "2", "n/a", "300"},
+		candidate: CandidateString,
+	},
+	ToleranceColumnTest{
+		column:       []Column{"1", "2", "n/a", "300"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateUint16,
+		failures:     1,
+		failedValues: []string{"n/a"},
+	},
+	ToleranceColumnTest{
+		column:    []Column{"1", "n/a", "x", "300"},
+		opts:      []Option{WithTolerance(1)},
+		candidate: CandidateString,
+	},
+	ToleranceColumnTest{
+		column:       []Column{"1", "n/a", "x", "300", "4", "5", "6", "7", "8", "9"},
+		opts:         []Option{WithToleranceFraction(0.2)},
+		candidate:    CandidateUint16,
+		failures:     2,
+		failedValues: []string{"n/a", "x"},
+	},
+	ToleranceColumnTest{
+		column:       []Column{"1", "n/a", "x", "300", "4", "5", "6", "7", "8", "9"},
+		opts:         []Option{WithToleranceFraction(0.2), WithFailedValuesLimit(1)},
+		candidate:    CandidateUint16,
+		failures:     2,
+		failedValues: []string{"n/a"},
+	},
+	// 300 fails uint8 but the tolerance lets it stay uint8
+	ToleranceColumnTest{
+		column:       []Column{"1", "2", "300"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateUint8,
+		failures:     1,
+		failedValues: []string{"300"},
+	},
+	ToleranceColumnTest{
+		column:       []Column{"1.5", "oops", "-2"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateFloat32,
+		failures:     1,
+		failedValues: []string{"oops"},
+	},
+	// Nothing but failures
+	ToleranceColumnTest{
+		column:    []Column{"n/a"},
+		opts:      []Option{WithTolerance(1)},
+		candidate: CandidateString,
+	},
+	ToleranceColumnTest{
+		column:       []Column{"true", "n/a", "false"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateBool,
+		failures:     1,
+		failedValues: []string{"n/a"},
+	},
+	ToleranceColumnTest{
+		column:       []Column{"2021-03-04", "n/a", "2021-03-05"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateTime,
+		failures:     1,
+		failedValues: []string{"n/a"},
+	},
+	ToleranceColumnTest{
+		column:       []Column{"1s", "n/a", "2m"},
+		opts:         []Option{WithTolerance(1)},
+		candidate:    CandidateDuration,
+		failures:     1,
+		failedValues: []string{"n/a"},
+	},
+	ToleranceColumnTest{
+		column:    []Column{"2021-03-04", "n/a", "x", "2021-03-05"},
+		opts:      []Option{WithTolerance(1)},
+		candidate: CandidateString,
+	},
+}
+
+func TestCasesTolerance(t *testing.T) {
+	for _, test := range testCasesTolerance {
+		ti := NewStringTyper(test.opts...)
+
+		for _, s := range test.column {
+			ti.CheckFieldTypeAndLength(string(s))
+		}
+
+		r := ti.Result()
+		if r.Candidate != test.candidate {
+			t.Error(test.column, test.candidate, r.Candidate)
+		}
+		if r.Failures != test.failures || !reflect.DeepEqual(r.FailedValues, test.failedValues) {
+			t.Error(test.column, "failures", test.failures, test.failedValues, r.Failures, r.FailedValues)
+		}
+	}
+}
+
+func TestToleranceFloatRange(t *testing.T) {
+	ti := NewStringTyper(WithTolerance(1))
+	for _, s := range []string{"1.5", "oops", "-2.5"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if ti.MinFloat == nil || *ti.MinFloat != -2.5 || ti.MaxFloat == nil || *ti.MaxFloat != 1.5 {
+		t.Fatal(ti.MinFloat, ti.MaxFloat)
+	}
+	if n := ti.Failures(CandidateInt8); n != 3 {
+		t.Fatal("int8 failures", n)
+	}
+}
+
+func TestNumbersRuledOut(t *testing.T) {
+	// Numbers stop being parsed once every numeric candidate is ruled out
+	// and has as many failing values kept as the limit
+	ti := NewStringTyper(WithLocales(LocaleEN), WithFailedValuesLimit(1))
+	for _, s := range []string{"12.5", "x", "300", "1,5", "NaN"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if ti.numeric() {
+		t.Fatal("numbers not ruled out")
+	}
+	if ti.MaxUint != nil || ti.MaxFloat != nil || ti.Precision() != 0 || ti.NaNCount() != 0 || ti.Locale() != "" {
+		t.Error("numbers kept", ti.MaxUint, ti.MaxFloat, ti.Precision(), ti.NaNCount(), ti.Locale())
+	}
+	if n := ti.Failures(CandidateUint8); n != 2 {
+		t.Error("uint8 failures", n)
+	}
+	if n := ti.Failures(CandidateFloat64); n != 1 {
+		t.Error("float64 failures", n)
+	}
+
+	ti = NewStringTyper(WithFailedValuesLimit(2))
+	for _, s := range []string{"12.5", "x"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if !ti.numeric() {
+		t.Error("numbers ruled out before the limit")
+	}
+
+	// Candidates that are not considered do not keep numbers parsed
+	ti = NewStringTyper(WithHugeInts(HugeIntFloat))
+	for i := 0; i < 100; i++ {
+		ti.CheckFieldTypeAndLength("x")
+	}
+	if n := ti.Failures(CandidateUint8); n != DefaultFailedValuesLimit {
+		t.Error("huge ints as floats: uint8 failures", n)
+	}
+
+	ti = NewStringTyper(WithCandidates(CandidateString))
+	for _, s := range []string{"1", "x", "300"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if ti.numeric() || ti.Failures(CandidateUint8) != 0 || ti.MaxUint != nil {
+		t.Error("no numeric candidates", ti.Failures(CandidateUint8), ti.MaxUint)
+	}
+
+	ti = NewStringTyper(WithTolerance(1))
+	for _, s := range []string{"x", "y", "300"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if ti.MaxUint == nil || *ti.MaxUint != 300 || ti.Failures(CandidateUint8) != 3 {
+		t.Error("tolerant", ti.MaxUint, ti.Failures(CandidateUint8))
+	}
+}
+
+func TestToleranceLocale(t *testing.T) {
+	// A stray value is a tolerated failure and rules no locale out
+	for _, stray := range []string{"x,y", "1,5"} {
+		ti := NewStringTyper(WithLocales(LocaleEN), WithTolerance(1))
+		for _, s := range []string{"1,234", stray, "2,345", "3,456"} {
+			ti.CheckFieldTypeAndLength(s)
+		}
+		if c := ti.Candidate(); c != CandidateUint16 {
+			t.Error(stray, "candidate", c)
+		}
+		if n := ti.Failures(CandidateUint16); n != 1 {
+			t.Error(stray, "failures", n)
+		}
+		if l := ti.Locale(); l != "en" {
+			t.Error(stray, "locale", l)
+		}
+		if ti.MaxUint == nil || *ti.MaxUint != 3456 {
+			t.Error(stray, "maxUint", ti.MaxUint)
+		}
+	}
+}
+
+func TestToleranceTime(t *testing.T) {
+	// A stray value rules no layout out, and the range is of the times
+	ti := NewStringTyper(WithToleranceFraction(0.5))
+	for _, s := range []string{"03/04/2020", "n/a", "x", "31/12/2020", "01/02/2021"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if c := ti.Candidate(); c != CandidateTime {
+		t.Error("candidate", c)
+	}
+	if n := ti.Failures(CandidateTime); n != 2 {
+		t.Error("failures", n)
+	}
+	if l := ti.TimeLayout(); l != "02/01/2006" {
+		t.Error("layout", l)
+	}
+	if ti.MinTime == nil || !ti.MinTime.Equal(time.Date(2020, 4, 3, 0, 0, 0, 0, time.UTC)) ||
+		ti.MaxTime == nil || !ti.MaxTime.Equal(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("range", ti.MinTime, ti.MaxTime)
+	}
+
+	// Without a tolerance time is only checked up to its first failure
+	ti = NewStringTyper()
+	for _, s := range []string{"2021-03-04", "n/a", "x"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if n := ti.Failures(CandidateTime); n != 1 || ti.MinTime != nil {
+		t.Error("not tolerant", n, ti.MinTime)
+	}
+}
+
+func TestToleranceBool(t *testing.T) {
+	ti := NewStringTyper(WithBoolVocabularies(BoolStrconv, BoolYesNo), WithTolerance(1))
+	for _, s := range []string{"yes", "n/a", "no"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if v := ti.BoolVocabulary(); v != "yes/no" {
+		t.Error("vocabulary", v)
+	}
+
+	// 0s and 1s with a stray value are still ambiguous
+	ti = NewStringTyper(WithBoolPolicy(PreferInteger), WithTolerance(1))
+	for _, s := range []string{"1", "0", "n/a", "1"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if !ti.Ambiguous() || ti.Candidate() != CandidateUint8 {
+		t.Error("ambiguous", ti.Ambiguous(), ti.Candidate())
+	}
+}
+
+func TestToleranceDuration(t *testing.T) {
+	ti := NewStringTyper(WithTolerance(1))
+	for _, s := range []string{"1s", "n/a", "2m"} {
+		ti.CheckFieldTypeAndLength(s)
+	}
+	if !ti.IsDuration() || ti.MinDuration == nil || *ti.MinDuration != time.Second ||
+		ti.MaxDuration == nil || *ti.MaxDuration != 2*time.Minute {
+		t.Error(ti.IsDuration(), ti.MinDuration, ti.MaxDuration)
+	}
+}